@@ -17,7 +17,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	"github.com/zclconf/go-cty/cty/function"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -169,6 +171,13 @@ type PluginInfo interface {
 	Name() string
 }
 
+// FunctionProvider provides the functions a plugin makes available
+// within HCL Vagrantfiles. The host adds these functions to the
+// evaluation context when loading a Vagrantfile.
+type FunctionProvider interface {
+	Functions() (map[string]function.Function, error)
+}
+
 type CommandFlags []*CommandFlag
 
 func (c CommandFlags) Display() string {
@@ -264,6 +273,18 @@ type ConfigRegistration struct {
 type ConfigData struct {
 	Source string                 // Root type name
 	Data   map[string]interface{} // Configuration data
+
+	// Provenance of leaf values keyed by their path within Data,
+	// with path segments joined by ".". This is only populated
+	// when provenance has been requested.
+	Provenance map[string]*Provenance
+}
+
+// Provenance describes where a configuration value was set
+type Provenance struct {
+	Layer string    // Merge layer which set the value (box, home, project, etc)
+	File  string    // Path of the file which set the value
+	Range hcl.Range // Location of the value within the file, if known
 }
 
 type ConfigMerge struct {
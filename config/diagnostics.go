@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+)
+
+// Diagnostics are the problems found while loading a Vagrantfile
+// along with the files the problems were found in. Diagnostics
+// can be used as an error.
+type Diagnostics struct {
+	hcl.Diagnostics
+
+	// Files are the parsed files referenced by the diagnostics. These
+	// are used for displaying source snippets.
+	Files map[string]*hcl.File
+}
+
+// Append adds diagnostics
+func (d *Diagnostics) Append(diags ...*hcl.Diagnostic) {
+	d.Diagnostics = append(d.Diagnostics, diags...)
+}
+
+// Errors returns only the diagnostics with error severity
+func (d *Diagnostics) Errors() hcl.Diagnostics {
+	return d.filter(hcl.DiagError)
+}
+
+// Warnings returns only the diagnostics with warning severity
+func (d *Diagnostics) Warnings() hcl.Diagnostics {
+	return d.filter(hcl.DiagWarning)
+}
+
+func (d *Diagnostics) filter(s hcl.DiagnosticSeverity) hcl.Diagnostics {
+	var result hcl.Diagnostics
+	for _, diag := range d.Diagnostics {
+		if diag.Severity == s {
+			result = append(result, diag)
+		}
+	}
+
+	return result
+}
+
+// Diagnostic is the machine readable representation of an hcl.Diagnostic
+type Diagnostic struct {
+	Severity string           `json:"severity"`
+	Summary  string           `json:"summary"`
+	Detail   string           `json:"detail,omitempty"`
+	Range    *DiagnosticRange `json:"range,omitempty"`
+	Snippet  string           `json:"snippet,omitempty"`
+}
+
+// DiagnosticRange is the location within a file of a Diagnostic
+type DiagnosticRange struct {
+	Filename string        `json:"filename"`
+	Start    DiagnosticPos `json:"start"`
+	End      DiagnosticPos `json:"end"`
+}
+
+// DiagnosticPos is a position within a file
+type DiagnosticPos struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	Byte   int `json:"byte"`
+}
+
+// List returns the machine readable representation of the diagnostics
+func (d *Diagnostics) List() []*Diagnostic {
+	result := make([]*Diagnostic, 0, len(d.Diagnostics))
+	for _, diag := range d.Diagnostics {
+		result = append(result, d.convert(diag))
+	}
+
+	return result
+}
+
+// MarshalJSON implements json.Marshaler
+func (d *Diagnostics) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.List())
+}
+
+func (d *Diagnostics) convert(diag *hcl.Diagnostic) *Diagnostic {
+	result := &Diagnostic{
+		Severity: severityString(diag.Severity),
+		Summary:  diag.Summary,
+		Detail:   diag.Detail,
+	}
+
+	if diag.Subject == nil {
+		return result
+	}
+
+	r := diag.Subject
+	result.Range = &DiagnosticRange{
+		Filename: r.Filename,
+		Start:    DiagnosticPos{Line: r.Start.Line, Column: r.Start.Column, Byte: r.Start.Byte},
+		End:      DiagnosticPos{Line: r.End.Line, Column: r.End.Column, Byte: r.End.Byte},
+	}
+
+	// Include the source line(s) of the subject when available
+	if f, ok := d.Files[r.Filename]; ok && f != nil {
+		lines := strings.Split(string(f.Bytes), "\n")
+		if r.Start.Line > 0 && r.End.Line <= len(lines) && r.Start.Line <= r.End.Line {
+			result.Snippet = strings.Join(lines[r.Start.Line-1:r.End.Line], "\n")
+		}
+	}
+
+	return result
+}
+
+// Render outputs the diagnostics to the UI. Source snippets are
+// included when the file content is available. If the UI is machine
+// readable, each diagnostic is output as a single table row.
+func (d *Diagnostics) Render(ui terminal.UI) error {
+	if len(d.Diagnostics) == 0 {
+		return nil
+	}
+
+	if ui.MachineReadable() {
+		d.renderMachineReadable(ui)
+		return nil
+	}
+
+	for _, diag := range d.Diagnostics {
+		var buf bytes.Buffer
+		w := hcl.NewDiagnosticTextWriter(&buf, d.Files, 0, false)
+		if err := w.WriteDiagnostic(diag); err != nil {
+			return err
+		}
+
+		style := terminal.WithErrorStyle()
+		if diag.Severity == hcl.DiagWarning {
+			style = terminal.WithWarningStyle()
+		}
+		ui.Output("%s", strings.TrimRight(buf.String(), "\n"), style)
+	}
+
+	return nil
+}
+
+func (d *Diagnostics) renderMachineReadable(ui terminal.UI) {
+	escape := strings.NewReplacer(
+		"\n", "\\n",
+		"\r", "\\r",
+		",", "%!(VAGRANT_COMMA)",
+	)
+
+	tbl := terminal.NewTable()
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	for _, diag := range d.List() {
+		var filename, line, column string
+		if diag.Range != nil {
+			filename = diag.Range.Filename
+			line = strconv.Itoa(diag.Range.Start.Line)
+			column = strconv.Itoa(diag.Range.Start.Column)
+		}
+
+		tbl.Rich([]string{
+			ts,
+			"config",
+			"diagnostic",
+			diag.Severity,
+			escape.Replace(filename),
+			line,
+			column,
+			escape.Replace(diag.Summary),
+			escape.Replace(diag.Detail),
+		}, nil)
+	}
+
+	ui.Table(tbl)
+}
+
+func severityString(s hcl.DiagnosticSeverity) string {
+	switch s {
+	case hcl.DiagError:
+		return "error"
+	case hcl.DiagWarning:
+		return "warning"
+	default:
+		return "invalid"
+	}
+}
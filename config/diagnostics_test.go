@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/stretchr/testify/require"
+)
+
+const testInvalidVagrantfile = `
+vm {
+  box          = "hashicorp/bionic64"
+  boot_timeout = "soon"
+}
+
+vagrant {
+  hots = "linux"
+}
+`
+
+func TestLoadVagrantfile_lenient(t *testing.T) {
+	require := require.New(t)
+
+	var diags Diagnostics
+	v, err := LoadVagrantfile([]byte(testInvalidVagrantfile), "Vagrantfile.hcl", HCL,
+		WithDiagnostics(&diags))
+	require.NoError(err)
+	require.Equal("hashicorp/bionic64", *v.VM.Box)
+
+	// Invalid values are errors
+	require.True(diags.HasErrors())
+	require.Len(diags.Errors(), 1)
+	require.Equal(4, diags.Errors()[0].Subject.Start.Line)
+
+	// Unknown arguments are warnings
+	require.Len(diags.Warnings(), 1)
+	require.Equal(8, diags.Warnings()[0].Subject.Start.Line)
+}
+
+func TestLoadVagrantfile_strict(t *testing.T) {
+	require := require.New(t)
+
+	v, err := LoadVagrantfile([]byte(testInvalidVagrantfile), "Vagrantfile.hcl", HCL,
+		WithStrict())
+	require.Error(err)
+	require.Nil(v)
+
+	var diags *Diagnostics
+	require.True(errors.As(err, &diags))
+	require.Len(diags.Errors(), 2)
+	require.Empty(diags.Warnings())
+	require.Contains(diags.Files, "Vagrantfile.hcl")
+
+	// Valid files still load in strict mode
+	_, err = LoadVagrantfile([]byte(`vm { box = "hashicorp/bionic64" }`), "Vagrantfile.hcl", HCL,
+		WithStrict())
+	require.NoError(err)
+}
+
+func TestDiagnostics_MarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	var diags Diagnostics
+	_, err := LoadVagrantfile([]byte(testInvalidVagrantfile), "Vagrantfile.hcl", HCL,
+		WithDiagnostics(&diags))
+	require.NoError(err)
+
+	content, err := json.Marshal(&diags)
+	require.NoError(err)
+
+	var result []*Diagnostic
+	require.NoError(json.Unmarshal(content, &result))
+	require.Len(result, 2)
+	require.Equal("error", result[0].Severity)
+	require.Equal("warning", result[1].Severity)
+	require.Equal("Vagrantfile.hcl", result[1].Range.Filename)
+	require.Equal(8, result[1].Range.Start.Line)
+	require.Equal(3, result[1].Range.Start.Column)
+	require.Contains(result[1].Snippet, "hots")
+}
+
+type testDiagUI struct {
+	terminal.UI
+
+	machineReadable bool
+	output          []string
+	tables          []*terminal.Table
+}
+
+func (u *testDiagUI) MachineReadable() bool { return u.machineReadable }
+
+func (u *testDiagUI) Output(msg string, raw ...interface{}) {
+	msg, _, _, _, _ = terminal.Interpret(msg, raw...)
+	u.output = append(u.output, msg)
+}
+
+func (u *testDiagUI) Table(tbl *terminal.Table, _ ...terminal.Option) {
+	u.tables = append(u.tables, tbl)
+}
+
+func TestDiagnostics_Render(t *testing.T) {
+	var diags Diagnostics
+	_, err := LoadVagrantfile([]byte(testInvalidVagrantfile), "Vagrantfile.hcl", HCL,
+		WithDiagnostics(&diags))
+	require.NoError(t, err)
+
+	t.Run("human readable", func(t *testing.T) {
+		require := require.New(t)
+
+		ui := &testDiagUI{}
+		require.NoError(diags.Render(ui))
+		require.Len(ui.output, 2)
+		require.Contains(ui.output[1], "Vagrantfile.hcl line 8")
+		require.Contains(ui.output[1], `hots = "linux"`)
+	})
+
+	t.Run("machine readable", func(t *testing.T) {
+		require := require.New(t)
+
+		ui := &testDiagUI{machineReadable: true}
+		require.NoError(diags.Render(ui))
+		require.Empty(ui.output)
+		require.Len(ui.tables, 1)
+
+		require.Len(ui.tables[0].Rows, 2)
+		row := ui.tables[0].Rows[1]
+		values := make([]string, len(row))
+		for i, e := range row {
+			values[i] = e.Value
+		}
+		require.Equal(
+			fmt.Sprintf("config,diagnostic,warning,Vagrantfile.hcl,8,3,%s", diags.Warnings()[0].Summary),
+			strings.Join(values[1:8], ","),
+		)
+	})
+}
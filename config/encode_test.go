@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/config"
+	"github.com/hashicorp/vagrant-plugin-sdk/internal-shared/cacher"
+	"github.com/hashicorp/vagrant-plugin-sdk/internal-shared/cleanup"
+	_ "github.com/hashicorp/vagrant-plugin-sdk/internal-shared/protomappers"
+	"github.com/hashicorp/vagrant-plugin-sdk/internal/pluginargs"
+)
+
+const testVagrantfile = `
+vagrant {
+  sensitive = ["secret"]
+}
+
+vm {
+  box          = "hashicorp/bionic64"
+  boot_timeout = 300
+
+  network "forwarded_port" {
+    guest = 80
+    host  = 8080
+  }
+
+  provider "virtualbox" {
+    memory    = 2048
+    customize = [["modifyvm", ":id", "--vram", "16"]]
+  }
+
+  provisioner "shell" {
+    inline = "echo hello"
+    env    = { NAME = "vagrant" }
+  }
+
+  synced_folder "." "/vagrant" {
+    mount_options = ["ro"]
+  }
+
+  usable_port_range {
+    start = 2200
+    end   = 2250
+  }
+}
+
+define_vm "web" {
+  primary = true
+}
+`
+
+func TestEncodeVagrantfile_roundTrip(t *testing.T) {
+	require := require.New(t)
+
+	v, err := config.LoadVagrantfile([]byte(testVagrantfile), "Vagrantfile.hcl", config.HCL,
+		config.WithStrict())
+	require.NoError(err)
+
+	log := hclog.NewNullLogger()
+	args := []argmapper.Arg{
+		argmapper.Typed(pluginargs.New(nil, cacher.New(), cleanup.New(), log, config.Mappers)),
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(log),
+	}
+
+	encoded, err := config.EncodeVagrantfile(v, args...)
+	require.NoError(err)
+
+	result, err := config.DecodeVagrantfile(encoded, args...)
+	require.NoError(err)
+
+	require.Equal([]string{"secret"}, result.Vagrant.Sensitive)
+	require.Equal(config.DefaultVMName, result.VM.Name)
+	require.Equal("hashicorp/bionic64", *result.VM.Box)
+	require.Equal(int32(300), *result.VM.BootTimeout)
+
+	require.Len(result.VM.Networks, 1)
+	require.Equal("forwarded_port", result.VM.Networks[0].Kind)
+	require.Equal(int32(8080), result.VM.Networks[0].ForwardedPort.Host)
+
+	require.Equal(int32(2048), result.VM.Providers[0].VirtualBox.Memory)
+	require.Equal(v.VM.Providers[0].VirtualBox.Customize, result.VM.Providers[0].VirtualBox.Customize)
+
+	require.Equal("echo hello", result.VM.Provisioners[0].Shell.Inline)
+	require.Equal(map[string]string{"NAME": "vagrant"}, result.VM.Provisioners[0].Shell.Env)
+
+	require.Equal("/vagrant", result.VM.SyncedFolders[0].Destination)
+	require.Equal([]string{"ro"}, result.VM.SyncedFolders[0].MountOptions)
+	require.Equal(*v.VM.UsablePortRange, *result.VM.UsablePortRange)
+
+	require.Len(result.DefinedVms, 1)
+	require.Equal("web", result.DefinedVms[0].Name)
+	require.True(*result.DefinedVms[0].Primary)
+}
@@ -0,0 +1,216 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// valuesSchema is the schema of the blocks which define
+// values available within an HCL Vagrantfile
+var valuesSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "var", LabelNames: []string{"name"}},
+		{Type: "locals"},
+	},
+}
+
+var varSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "default"},
+		{Name: "description"},
+	},
+}
+
+// evalContext builds the context used for decoding an HCL Vagrantfile.
+// The var and locals blocks are evaluated and removed from the
+// returned body.
+func evalContext(
+	body hcl.Body, // body of the Vagrantfile
+	baseDir string, // directory relative paths are expanded from
+	c *loadConfig,
+) (*hcl.EvalContext, hcl.Body, hcl.Diagnostics) {
+	funcs := Functions(baseDir)
+	for name, fn := range c.funcs {
+		funcs[name] = fn
+	}
+
+	ctx := &hcl.EvalContext{
+		Functions: funcs,
+		Variables: map[string]cty.Value{},
+	}
+
+	content, remain, diags := body.PartialContent(valuesSchema)
+	if diags.HasErrors() {
+		return nil, nil, diags
+	}
+
+	vars := map[string]cty.Value{}
+	varRanges := map[string]hcl.Range{}
+	var locals []*hcl.Attribute
+	localRanges := map[string]hcl.Range{}
+
+	for _, blk := range content.Blocks {
+		switch blk.Type {
+		case "var":
+			name := blk.Labels[0]
+			if r, ok := varRanges[name]; ok {
+				diags = append(diags, duplicateDiag("variable", name, r, blk.LabelRanges[0]))
+				continue
+			}
+			varRanges[name] = blk.LabelRanges[0]
+
+			val, d := evalVar(ctx, blk, c.variables)
+			diags = diags.Extend(d)
+			vars[name] = val
+		case "locals":
+			attrs, d := blk.Body.JustAttributes()
+			diags = diags.Extend(d)
+			for _, attr := range attrs {
+				if r, ok := localRanges[attr.Name]; ok {
+					diags = append(diags, duplicateDiag("local value", attr.Name, r, attr.NameRange))
+					continue
+				}
+				localRanges[attr.Name] = attr.NameRange
+				locals = append(locals, attr)
+			}
+		}
+	}
+
+	ctx.Variables["var"] = cty.ObjectVal(vars)
+	ctx.Variables["local"], diags = evalLocals(ctx, locals, diags)
+
+	return ctx, remain, diags
+}
+
+func evalVar(
+	ctx *hcl.EvalContext,
+	blk *hcl.Block,
+	values map[string]cty.Value,
+) (cty.Value, hcl.Diagnostics) {
+	content, diags := blk.Body.Content(varSchema)
+	if diags.HasErrors() {
+		return cty.DynamicVal, diags
+	}
+
+	if v, ok := values[blk.Labels[0]]; ok {
+		return v, diags
+	}
+
+	def, ok := content.Attributes["default"]
+	if !ok {
+		return cty.DynamicVal, diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "No value for required variable",
+			Detail: fmt.Sprintf("The variable %q has no default value and no value was provided.",
+				blk.Labels[0]),
+			Subject: blk.DefRange.Ptr(),
+		})
+	}
+
+	// Variable defaults can only use functions
+	val, d := def.Expr.Value(&hcl.EvalContext{Functions: ctx.Functions})
+
+	return val, diags.Extend(d)
+}
+
+// Local values may reference each other, so evaluate each once all
+// of the local values it references are known.
+func evalLocals(
+	ctx *hcl.EvalContext,
+	attrs []*hcl.Attribute,
+	diags hcl.Diagnostics,
+) (cty.Value, hcl.Diagnostics) {
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Range.Start.Byte < attrs[j].Range.Start.Byte
+	})
+
+	defined := map[string]struct{}{}
+	for _, attr := range attrs {
+		defined[attr.Name] = struct{}{}
+	}
+
+	locals := map[string]cty.Value{}
+	pending := attrs
+	for len(pending) > 0 {
+		var next []*hcl.Attribute
+		for _, attr := range pending {
+			if !localReady(attr, defined, locals) {
+				next = append(next, attr)
+				continue
+			}
+
+			ctx.Variables["local"] = cty.ObjectVal(locals)
+			val, d := attr.Expr.Value(ctx)
+			diags = diags.Extend(d)
+			if d.HasErrors() {
+				val = cty.DynamicVal
+			}
+			locals[attr.Name] = val
+		}
+
+		// If nothing was evaluated the remaining local
+		// values must reference each other
+		if len(next) == len(pending) {
+			for _, attr := range next {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Circular reference in local value",
+					Detail: fmt.Sprintf("The local value %q cannot be evaluated because of a circular reference.",
+						attr.Name),
+					Subject: attr.NameRange.Ptr(),
+				})
+				locals[attr.Name] = cty.DynamicVal
+			}
+			break
+		}
+
+		pending = next
+	}
+
+	return cty.ObjectVal(locals), diags
+}
+
+// localReady returns if all the local values referenced by the
+// attribute have been evaluated
+func localReady(
+	attr *hcl.Attribute,
+	defined map[string]struct{},
+	locals map[string]cty.Value,
+) bool {
+	for _, t := range attr.Expr.Variables() {
+		if t.RootName() != "local" || len(t) < 2 {
+			continue
+		}
+
+		step, ok := t[1].(hcl.TraverseAttr)
+		if !ok {
+			continue
+		}
+
+		if _, ok := defined[step.Name]; !ok {
+			continue
+		}
+
+		if _, ok := locals[step.Name]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func duplicateDiag(kind, name string, prev, cur hcl.Range) *hcl.Diagnostic {
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  fmt.Sprintf("Duplicate %s", kind),
+		Detail: fmt.Sprintf("The %s %q was already defined at %s.",
+			kind, name, prev),
+		Subject: cur.Ptr(),
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+func TestLoadVagrantfile_eval(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := os.MkdirTemp("", "configtest")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(os.WriteFile(filepath.Join(dir, "key.pub"), []byte("ssh-rsa AAAA"), 0644))
+	require.NoError(os.WriteFile(filepath.Join(dir, "motd.tpl"), []byte("Welcome to ${upper(name)}"), 0644))
+	t.Setenv("VAGRANT_TEST_BOX", "hashicorp/focal64")
+
+	content := []byte(`
+var "network" {
+  default = "10.10.0.0/16"
+}
+
+var "box" {
+  default = "hashicorp/bionic64"
+}
+
+locals {
+  web_ip  = cidrhost(local.subnet, 10)
+  subnet  = cidrsubnet(var.network, 8, 2)
+  options = merge({ a = "1" }, { b = "2" }, { a = "3" })
+}
+
+vm {
+  box             = env("VAGRANT_TEST_BOX", var.box)
+  hostname        = lower("WEB")
+  post_up_message = templatefile("motd.tpl", { name = "web" })
+
+  box_download_options = local.options
+}
+
+define_vm "web" {
+  box      = var.box
+  hostname = local.web_ip
+}
+
+define_vm "db" {
+  hostname = trim(file("key.pub"))
+}
+`)
+
+	trim := function.New(&function.Spec{
+		Params: []function.Parameter{{Name: "str", Type: cty.String}},
+		Type:   function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			return cty.StringVal(args[0].AsString()[:7]), nil
+		},
+	})
+
+	var diags Diagnostics
+	v, err := LoadVagrantfile(content, filepath.Join(dir, "Vagrantfile.hcl"), HCL,
+		WithStrict(),
+		WithDiagnostics(&diags),
+		WithFunctions(map[string]function.Function{"trim": trim}),
+		WithVariables(map[string]cty.Value{"box": cty.StringVal("hashicorp/jammy64")}),
+	)
+	require.NoError(err, diags.Error())
+
+	require.Equal("hashicorp/focal64", *v.VM.Box)
+	require.Equal("web", *v.VM.Hostname)
+	require.Equal("Welcome to WEB", *v.VM.PostUpMessage)
+	require.Equal(map[string]string{"a": "3", "b": "2"}, v.VM.BoxDownloadOptions)
+
+	require.Equal("hashicorp/jammy64", *v.DefinedVms[0].Box)
+	require.Equal("10.10.2.10", *v.DefinedVms[0].Hostname)
+	require.Equal("ssh-rsa", *v.DefinedVms[1].Hostname)
+
+	// The context is available to plugins decoding remaining blocks
+	require.NotNil(v.EvalContext)
+	require.Contains(v.EvalContext.Variables, "local")
+}
+
+func TestLoadVagrantfile_evalErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		summary string
+	}{
+		{
+			"required variable",
+			`var "box" {}`,
+			"No value for required variable",
+		},
+		{
+			"duplicate variable",
+			"var \"box\" {\n default = 1\n}\nvar \"box\" {\n default = 2\n}",
+			"Duplicate variable",
+		},
+		{
+			"circular local",
+			"locals {\n a = local.b\n b = local.a\n}",
+			"Circular reference in local value",
+		},
+		{
+			"unknown function",
+			"locals {\n a = nope()\n}",
+			"Call to unknown function",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var diags Diagnostics
+			_, err := LoadVagrantfile([]byte(tc.content), "Vagrantfile.hcl", HCL,
+				WithDiagnostics(&diags))
+			require.Error(err)
+			require.True(diags.HasErrors())
+			require.Equal(tc.summary, diags.Errors()[0].Summary)
+		})
+	}
+}
+
+func TestFunctions_cidr(t *testing.T) {
+	cases := []struct {
+		fn       function.Function
+		args     []cty.Value
+		expected string
+		err      bool
+	}{
+		{CidrSubnetFunc, []cty.Value{cty.StringVal("172.16.0.0/12"), cty.NumberIntVal(4), cty.NumberIntVal(2)}, "172.18.0.0/16", false},
+		{CidrSubnetFunc, []cty.Value{cty.StringVal("fd00:fd12:3456:7890::/56"), cty.NumberIntVal(16), cty.NumberIntVal(162)}, "fd00:fd12:3456:7800:a200::/72", false},
+		{CidrSubnetFunc, []cty.Value{cty.StringVal("10.0.0.0/30"), cty.NumberIntVal(4), cty.NumberIntVal(0)}, "", true},
+		{CidrSubnetFunc, []cty.Value{cty.StringVal("10.0.0.0/16"), cty.NumberIntVal(2), cty.NumberIntVal(4)}, "", true},
+		{CidrHostFunc, []cty.Value{cty.StringVal("10.12.112.0/20"), cty.NumberIntVal(16)}, "10.12.112.16", false},
+		{CidrHostFunc, []cty.Value{cty.StringVal("10.12.112.0/20"), cty.NumberIntVal(-2)}, "10.12.127.254", false},
+		{CidrHostFunc, []cty.Value{cty.StringVal("10.0.0.0/30"), cty.NumberIntVal(4)}, "", true},
+	}
+
+	for _, tc := range cases {
+		result, err := tc.fn.Call(tc.args)
+		if tc.err {
+			require.Error(t, err, "args: %#v", tc.args)
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, result.AsString())
+	}
+}
+
+func TestRegisterFunction(t *testing.T) {
+	require := require.New(t)
+
+	fn := function.New(&function.Spec{
+		Type: function.StaticReturnType(cty.String),
+		Impl: func([]cty.Value, cty.Type) (cty.Value, error) {
+			return cty.StringVal("registered"), nil
+		},
+	})
+
+	require.Error(RegisterFunction("env", fn))
+	require.NoError(RegisterFunction("test_registered", fn))
+	require.Error(RegisterFunction("test_registered", fn))
+
+	v, err := LoadVagrantfile([]byte(`vm { box = test_registered() }`), "Vagrantfile.hcl", HCL)
+	require.NoError(err)
+	require.Equal("registered", *v.VM.Box)
+
+	// Plugins can decode their blocks using the same context
+	v, err = LoadVagrantfile([]byte("locals {\n mem = 512\n}\nvm {\n provider \"libvirt\" {\n memory = local.mem\n }\n}"),
+		"Vagrantfile.hcl", HCL)
+	require.NoError(err)
+
+	var extra struct {
+		Memory int `hcl:"memory"`
+	}
+	d := gohcl.DecodeBody(v.VM.Providers[0].Remain, v.EvalContext, &extra)
+	require.False(d.HasErrors(), d.Error())
+	require.Equal(512, extra.Memory)
+}
@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"fmt"
+)
+
+// Finalize resolves the machines defined within the Vagrantfile. The
+// global vm block is applied to each define_vm block, in the order
+// they are defined, and the results are stored in ListVMs with their
+// names in DefinedVmKeys. Only when no define_vm blocks exist does the
+// global vm block define a single machine of its own.
+//
+// Repeated define_vm blocks with the same name are merged. Like all
+// other values, the global primary and autostart values are inherited
+// by each machine unless the define_vm block sets them. Autostart
+// defaults to true and the primary machine is the one marked primary
+// or, when only a single machine is defined, that machine.
+func (v *Vagrantfile) Finalize() error {
+	global := &VM{}
+	if v.VM != nil {
+		*global = *v.VM
+	}
+
+	var (
+		keys []string
+		vms  = map[string]*VM{}
+	)
+
+	defined := v.DefinedVms
+	if len(defined) == 0 {
+		name := DefaultVMName
+		if global.Name != "" {
+			name = global.Name
+		}
+		defined = []*VM{{Name: name}}
+	}
+
+	for _, d := range defined {
+		base, ok := vms[d.Name]
+		if !ok {
+			base = global
+			keys = append(keys, d.Name)
+		}
+
+		vm, err := base.Merge(d)
+		if err != nil {
+			return fmt.Errorf("failed to apply vm configuration to %q: %w", d.Name, err)
+		}
+		vm.Name = d.Name
+		vms[d.Name] = vm
+	}
+
+	list := make([]*VM, 0, len(keys))
+	primary := ""
+	for _, k := range keys {
+		vm := vms[k]
+		if vm.Primary != nil && *vm.Primary {
+			if primary != "" {
+				return fmt.Errorf("multiple machines marked as primary: %q and %q", primary, k)
+			}
+			primary = k
+		}
+		list = append(list, vm)
+	}
+	if primary == "" && len(list) == 1 {
+		primary = list[0].Name
+	}
+
+	for _, vm := range list {
+		isPrimary := vm.Name == primary
+		vm.Primary = &isPrimary
+		if vm.AutoStart == nil {
+			autostart := true
+			vm.AutoStart = &autostart
+		}
+	}
+
+	v.DefinedVmKeys = keys
+	v.ListVMs = list
+
+	return nil
+}
+
+// TargetNames returns the names of the machines defined
+// within the Vagrantfile
+func (v *Vagrantfile) TargetNames() []string {
+	return v.DefinedVmKeys
+}
+
+// PrimaryTargetName returns the name of the primary machine. An
+// empty name is returned if there is no primary machine.
+func (v *Vagrantfile) PrimaryTargetName() string {
+	for _, vm := range v.ListVMs {
+		if vm.Primary != nil && *vm.Primary {
+			return vm.Name
+		}
+	}
+
+	return ""
+}
+
+// TargetConfig returns the Vagrantfile for a single machine. The
+// machine configuration is set as the vm block of the result.
+func (v *Vagrantfile) TargetConfig(name string) (*Vagrantfile, error) {
+	for _, vm := range v.ListVMs {
+		if vm.Name != name {
+			continue
+		}
+
+		return &Vagrantfile{
+			Communicators: v.Communicators,
+			SSH:           v.SSH,
+			Vagrant:       v.Vagrant,
+			VM:            vm,
+			DefinedVmKeys: []string{name},
+			ListVMs:       []*VM{vm},
+			Body:          v.Body,
+			Remain:        v.Remain,
+			EvalContext:   v.EvalContext,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no machine named %q is defined", name)
+}
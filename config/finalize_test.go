@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVagrantfile_Finalize(t *testing.T) {
+	require := require.New(t)
+
+	v, err := LoadVagrantfile([]byte(`
+vm {
+  box       = "hashicorp/bionic64"
+  autostart = false
+}
+
+define_vm "web" {
+  hostname = "web"
+  primary  = true
+}
+
+define_vm "db" {
+  box       = "hashicorp/focal64"
+  autostart = false
+}
+
+define_vm "web" {
+  box = "hashicorp/jammy64"
+}
+`), "Vagrantfile.hcl", HCL, WithStrict())
+	require.NoError(err)
+
+	require.Equal([]string{"web", "db"}, v.TargetNames())
+	require.Equal("web", v.PrimaryTargetName())
+
+	web := v.ListVMs[0]
+	require.Equal("web", *web.Hostname)
+	require.Equal("hashicorp/jammy64", *web.Box)
+	require.False(*web.AutoStart)
+
+	db := v.ListVMs[1]
+	require.Equal("hashicorp/focal64", *db.Box)
+	require.False(*db.AutoStart)
+	require.False(*db.Primary)
+
+	target, err := v.TargetConfig("db")
+	require.NoError(err)
+	require.Equal("db", target.VM.Name)
+	require.Equal([]string{"db"}, target.TargetNames())
+
+	_, err = v.TargetConfig("missing")
+	require.Error(err)
+
+	// The global vm block does not define a machine of its own
+	_, err = v.TargetConfig(DefaultVMName)
+	require.Error(err)
+}
+
+func TestVagrantfile_FinalizeInherit(t *testing.T) {
+	require := require.New(t)
+
+	v, err := LoadVagrantfile([]byte(`
+vm {
+  primary   = true
+  autostart = false
+}
+
+define_vm "web" {}
+`), "Vagrantfile.hcl", HCL, WithStrict())
+	require.NoError(err)
+
+	require.Equal([]string{"web"}, v.TargetNames())
+	require.Equal("web", v.PrimaryTargetName())
+	require.False(*v.ListVMs[0].AutoStart)
+
+	v, err = LoadVagrantfile([]byte(`
+vm {
+  autostart = false
+}
+
+define_vm "web" {
+  autostart = true
+}
+
+define_vm "db" {}
+`), "Vagrantfile.hcl", HCL, WithStrict())
+	require.NoError(err)
+
+	require.Equal([]string{"web", "db"}, v.TargetNames())
+	require.True(*v.ListVMs[0].AutoStart)
+	require.False(*v.ListVMs[1].AutoStart)
+	require.Equal("", v.PrimaryTargetName())
+}
+
+func TestVagrantfile_FinalizeSingle(t *testing.T) {
+	require := require.New(t)
+
+	v, err := LoadVagrantfile([]byte(`vm { box = "hashicorp/bionic64" }`),
+		"Vagrantfile.hcl", HCL)
+	require.NoError(err)
+
+	require.Equal([]string{DefaultVMName}, v.TargetNames())
+	require.Equal(DefaultVMName, v.PrimaryTargetName())
+	require.Equal("hashicorp/bionic64", *v.ListVMs[0].Box)
+	require.True(*v.ListVMs[0].AutoStart)
+}
+
+func TestVagrantfile_FinalizeMultiplePrimary(t *testing.T) {
+	require := require.New(t)
+
+	var diags Diagnostics
+	_, err := LoadVagrantfile([]byte(`
+define_vm "web" {
+  primary = true
+}
+
+define_vm "db" {
+  primary = true
+}
+`), "Vagrantfile.hcl", HCL, WithStrict(), WithDiagnostics(&diags))
+	require.Error(err)
+	require.Equal("Invalid machine definitions", diags.Errors()[0].Summary)
+}
@@ -0,0 +1,319 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+var (
+	funcsLock       sync.Mutex
+	registeredFuncs = map[string]function.Function{}
+)
+
+// RegisterFunction registers a function which will be available
+// within HCL Vagrantfiles loaded by this process. Registering a
+// function with the same name as an existing function is an error.
+func RegisterFunction(name string, fn function.Function) error {
+	funcsLock.Lock()
+	defer funcsLock.Unlock()
+
+	if _, ok := standardFunctions("")[name]; ok {
+		return fmt.Errorf("cannot register function %q, name is reserved", name)
+	}
+
+	if _, ok := registeredFuncs[name]; ok {
+		return fmt.Errorf("function %q is already registered", name)
+	}
+
+	registeredFuncs[name] = fn
+
+	return nil
+}
+
+// Functions returns all the functions available within an HCL
+// Vagrantfile. Paths provided to functions which read files are
+// relative to the given base directory.
+func Functions(baseDir string) map[string]function.Function {
+	funcs := standardFunctions(baseDir)
+
+	funcsLock.Lock()
+	defer funcsLock.Unlock()
+
+	for name, fn := range registeredFuncs {
+		funcs[name] = fn
+	}
+
+	return funcs
+}
+
+func standardFunctions(baseDir string) map[string]function.Function {
+	funcs := map[string]function.Function{
+		"cidrhost":   CidrHostFunc,
+		"cidrsubnet": CidrSubnetFunc,
+		"coalesce":   stdlib.CoalesceFunc,
+		"concat":     stdlib.ConcatFunc,
+		"env":        EnvFunc,
+		"file":       MakeFileFunc(baseDir),
+		"format":     stdlib.FormatFunc,
+		"jsondecode": stdlib.JSONDecodeFunc,
+		"jsonencode": stdlib.JSONEncodeFunc,
+		"length":     stdlib.LengthFunc,
+		"lower":      stdlib.LowerFunc,
+		"merge":      MergeFunc,
+		"upper":      stdlib.UpperFunc,
+	}
+
+	// Templates can use all the functions except for
+	// templatefile itself
+	tmplFuncs := map[string]function.Function{}
+	for name, fn := range funcs {
+		tmplFuncs[name] = fn
+	}
+	funcs["templatefile"] = MakeTemplateFileFunc(baseDir, tmplFuncs)
+
+	return funcs
+}
+
+// EnvFunc returns the value of an environment variable. An optional
+// second argument is used as the default when the variable is unset.
+var EnvFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "name", Type: cty.String},
+	},
+	VarParam: &function.Parameter{
+		Name: "default",
+		Type: cty.String,
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		if len(args) > 2 {
+			return cty.NilVal, fmt.Errorf("too many arguments, expected at most 2")
+		}
+
+		if v, ok := os.LookupEnv(args[0].AsString()); ok {
+			return cty.StringVal(v), nil
+		}
+
+		if len(args) == 2 {
+			return args[1], nil
+		}
+
+		return cty.StringVal(""), nil
+	},
+})
+
+// MakeFileFunc constructs a function that reads the contents of a
+// file. Relative paths are expanded from the base directory.
+func MakeFileFunc(baseDir string) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "path", Type: cty.String},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			content, err := readFile(baseDir, args[0].AsString())
+			if err != nil {
+				return cty.NilVal, err
+			}
+
+			return cty.StringVal(string(content)), nil
+		},
+	})
+}
+
+// MakeTemplateFileFunc constructs a function that renders a template
+// file using the provided variables. The given functions are available
+// within the template.
+func MakeTemplateFileFunc(
+	baseDir string,
+	funcs map[string]function.Function,
+) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "path", Type: cty.String},
+			{Name: "vars", Type: cty.DynamicPseudoType},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			p := args[0].AsString()
+			content, err := readFile(baseDir, p)
+			if err != nil {
+				return cty.NilVal, err
+			}
+
+			vars := args[1]
+			if !vars.Type().IsObjectType() && !vars.Type().IsMapType() {
+				return cty.NilVal, function.NewArgErrorf(1, "invalid vars value: must be a map")
+			}
+
+			expr, diags := hclsyntax.ParseTemplate(content, p, hcl.Pos{Line: 1, Column: 1})
+			if diags.HasErrors() {
+				return cty.NilVal, diags
+			}
+
+			ctx := &hcl.EvalContext{
+				Functions: funcs,
+				Variables: vars.AsValueMap(),
+			}
+			val, diags := expr.Value(ctx)
+			if diags.HasErrors() {
+				return cty.NilVal, diags
+			}
+
+			return val, nil
+		},
+	})
+}
+
+func readFile(baseDir, p string) ([]byte, error) {
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(baseDir, p)
+	}
+
+	content, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %q: %w", p, err)
+	}
+
+	return content, nil
+}
+
+// MergeFunc merges maps or objects together. When keys are duplicated
+// the value of the last argument is used.
+var MergeFunc = function.New(&function.Spec{
+	VarParam: &function.Parameter{
+		Name:             "maps",
+		Type:             cty.DynamicPseudoType,
+		AllowDynamicType: true,
+		AllowNull:        true,
+	},
+	Type: function.StaticReturnType(cty.DynamicPseudoType),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		result := map[string]cty.Value{}
+		for i, arg := range args {
+			if arg.IsNull() {
+				continue
+			}
+
+			ty := arg.Type()
+			if !ty.IsObjectType() && !ty.IsMapType() {
+				return cty.NilVal, function.NewArgErrorf(i, "arguments must be maps or objects, got %s", ty.FriendlyName())
+			}
+
+			for k, v := range arg.AsValueMap() {
+				result[k] = v
+			}
+		}
+
+		return cty.ObjectVal(result), nil
+	},
+})
+
+// CidrSubnetFunc calculates a subnet address within the given
+// IP network address prefix.
+var CidrSubnetFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "prefix", Type: cty.String},
+		{Name: "newbits", Type: cty.Number},
+		{Name: "netnum", Type: cty.Number},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		var newbits int
+		var netnum int64
+		if err := gocty.FromCtyValue(args[1], &newbits); err != nil {
+			return cty.NilVal, function.NewArgError(1, err)
+		}
+		if err := gocty.FromCtyValue(args[2], &netnum); err != nil {
+			return cty.NilVal, function.NewArgError(2, err)
+		}
+
+		_, network, err := net.ParseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.NilVal, function.NewArgErrorf(0, "invalid CIDR expression: %s", err)
+		}
+
+		ones, bits := network.Mask.Size()
+		newPrefix := ones + newbits
+		if newbits < 0 || newPrefix > bits {
+			return cty.NilVal, function.NewArgErrorf(1,
+				"cannot extend prefix /%d by %d bits", ones, newbits)
+		}
+
+		max := new(big.Int).Lsh(big.NewInt(1), uint(newbits))
+		num := big.NewInt(netnum)
+		if netnum < 0 || num.Cmp(max) >= 0 {
+			return cty.NilVal, function.NewArgErrorf(2,
+				"prefix extension of %d bits does not accommodate subnet number %d", newbits, netnum)
+		}
+
+		ip := addIPOffset(network.IP, num.Lsh(num, uint(bits-newPrefix)))
+		result := &net.IPNet{IP: ip, Mask: net.CIDRMask(newPrefix, bits)}
+
+		return cty.StringVal(result.String()), nil
+	},
+})
+
+// CidrHostFunc calculates a host IP address within the given IP
+// network address prefix. Negative host numbers count back from
+// the end of the range.
+var CidrHostFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "prefix", Type: cty.String},
+		{Name: "hostnum", Type: cty.Number},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		var hostnum int64
+		if err := gocty.FromCtyValue(args[1], &hostnum); err != nil {
+			return cty.NilVal, function.NewArgError(1, err)
+		}
+
+		_, network, err := net.ParseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.NilVal, function.NewArgErrorf(0, "invalid CIDR expression: %s", err)
+		}
+
+		ones, bits := network.Mask.Size()
+		size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+		num := big.NewInt(hostnum)
+		if hostnum < 0 {
+			num.Add(size, num)
+		}
+		if num.Sign() < 0 || num.Cmp(size) >= 0 {
+			return cty.NilVal, function.NewArgErrorf(1,
+				"prefix of %d bits cannot accommodate host number %d", ones, hostnum)
+		}
+
+		return cty.StringVal(addIPOffset(network.IP, num).String()), nil
+	},
+})
+
+func addIPOffset(ip net.IP, offset *big.Int) net.IP {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+
+	val := new(big.Int).SetBytes(ip)
+	val.Add(val, offset)
+
+	result := make(net.IP, len(ip))
+	b := val.Bytes()
+	copy(result[len(result)-len(b):], b)
+
+	return result
+}
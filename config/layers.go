@@ -0,0 +1,416 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/config/merge"
+	"github.com/hashicorp/vagrant-plugin-sdk/core"
+	"github.com/hashicorp/vagrant-plugin-sdk/helper/path"
+)
+
+// Names of the layers a Vagrantfile is built from. Layers are
+// merged in the order listed, with later layers taking precedence.
+// The define_vm blocks from all layers are applied last.
+const (
+	LayerBox      = "box"
+	LayerHome     = "home"
+	LayerProject  = "project"
+	LayerDefineVM = "define_vm"
+)
+
+// Names of the Vagrantfile packaged within a box, in the order
+// they are searched
+var boxVagrantfileNames = []string{HCLVagrantfileName, "Vagrantfile"}
+
+// Origin describes where a configuration value was set
+type Origin struct {
+	Layer string    // Name of the layer which set the value
+	File  path.Path // Vagrantfile which set the value, if known
+	Range hcl.Range // Location of the value, if known
+}
+
+func (o *Origin) String() string {
+	if o.File == nil {
+		return o.Layer
+	}
+	if o.Range.Start.Line > 0 {
+		return fmt.Sprintf("%s (%s:%d)", o.Layer, o.File, o.Range.Start.Line)
+	}
+
+	return fmt.Sprintf("%s (%s)", o.Layer, o.File)
+}
+
+// Provenance converts the origin for use within ConfigData
+func (o *Origin) Provenance() *component.Provenance {
+	p := &component.Provenance{Layer: o.Layer, Range: o.Range}
+	if o.File != nil {
+		p.File = o.File.String()
+	}
+
+	return p
+}
+
+// Layer is a loaded Vagrantfile to be merged with others
+type Layer struct {
+	Name        string    // Name of the layer, like LayerProject
+	File        path.Path // Path of the Vagrantfile
+	Vagrantfile *Vagrantfile
+}
+
+// BoxLookup returns the box used by the machine. A nil box
+// may be returned if the machine does not use a box.
+type BoxLookup func(vm *VM) (core.Box, error)
+
+// MergeLayers merges the layers in the order given and finalizes
+// the result. The global vm blocks of all layers are merged first
+// and the define_vm blocks of all layers are then applied. The
+// layer which set each value is recorded within the Origins of the
+// result and of each machine.
+//
+// Content which is not decoded, like plugin configuration blocks,
+// is merged from all of the layers in order.
+func MergeLayers(layers ...*Layer) (*Vagrantfile, error) {
+	result := &Vagrantfile{}
+	var remain []hcl.Body
+	for _, l := range layers {
+		if l == nil || l.Vagrantfile == nil {
+			continue
+		}
+		v := l.Vagrantfile
+
+		var err error
+		if result.Vagrant, err = merge.Structs(result.Vagrant, v.Vagrant); err != nil {
+			return nil, fmt.Errorf("failed to merge %s vagrant configuration: %w", l.Name, err)
+		}
+		if result.SSH, err = merge.Structs(result.SSH, v.SSH); err != nil {
+			return nil, fmt.Errorf("failed to merge %s ssh configuration: %w", l.Name, err)
+		}
+		if result.VM, err = result.VM.Merge(v.VM); err != nil {
+			return nil, fmt.Errorf("failed to merge %s vm configuration: %w", l.Name, err)
+		}
+		result.Communicators = append(result.Communicators, v.Communicators...)
+		result.DefinedVms = append(result.DefinedVms, v.DefinedVms...)
+
+		if v.Body != nil {
+			result.Body = v.Body
+		}
+		if v.Remain != nil {
+			remain = append(remain, v.Remain)
+		}
+		if v.EvalContext != nil {
+			result.EvalContext = v.EvalContext
+		}
+	}
+
+	switch len(remain) {
+	case 0:
+	case 1:
+		result.Remain = remain[0]
+	default:
+		result.Remain = &layeredBody{Body: hcl.MergeBodies(remain), bodies: remain}
+	}
+
+	if err := result.Finalize(); err != nil {
+		return nil, err
+	}
+	recordOrigins(result, layers)
+
+	return result, nil
+}
+
+// layeredBody is the merge of the remaining bodies of several
+// layers. The bodies of the layers are kept so each can be written.
+type layeredBody struct {
+	hcl.Body
+	bodies []hcl.Body
+}
+
+// BoxVagrantfile returns the path of the Vagrantfile packaged
+// within the box. A nil path is returned when the box does not
+// include a Vagrantfile.
+func BoxVagrantfile(box core.Box) (path.Path, error) {
+	dir, err := box.Directory()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range boxVagrantfileNames {
+		if p := dir.Join(name); p.Exists() {
+			return p, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// LoadLayeredVagrantfile loads the project Vagrantfile and merges it
+// with the Vagrantfile within the home directory and the Vagrantfile
+// packaged within the box of each machine, unless the machine sets
+// ignore_box_vagrantfile. The layers are merged in the order of box,
+// home, project and then define_vm. The home path may be nil. Ruby
+// Vagrantfiles are loaded using the ruby runtime.
+func LoadLayeredVagrantfile(
+	home path.Path, // path to home Vagrantfile
+	project path.Path, // path to project Vagrantfile
+	boxes BoxLookup, // lookup for machine boxes
+	rubyRuntime parser, // ruby runtime plugin
+	opts ...LoadOption,
+) (*Vagrantfile, error) {
+	var layers []*Layer
+	for _, l := range []*Layer{
+		{Name: LayerHome, File: home},
+		{Name: LayerProject, File: project},
+	} {
+		if l.File == nil || !l.File.Exists() {
+			continue
+		}
+		v, err := loadVagrantfileFile(l.File, rubyRuntime, append(opts, WithLayer(l.Name))...)
+		if err != nil {
+			return nil, err
+		}
+		l.Vagrantfile = v
+		layers = append(layers, l)
+	}
+
+	result, err := MergeLayers(layers...)
+	if err != nil {
+		return nil, err
+	}
+	if boxes == nil {
+		return result, nil
+	}
+
+	// The box is only known once the machine is resolved, so
+	// the box layer is applied to each machine individually
+	loaded := map[string]*Layer{}
+	for i, vm := range result.ListVMs {
+		if vm.IgnoreBoxVagrantfile != nil && *vm.IgnoreBoxVagrantfile {
+			continue
+		}
+		box, err := boxes(vm)
+		if err != nil {
+			return nil, err
+		}
+		if box == nil {
+			continue
+		}
+		p, err := BoxVagrantfile(box)
+		if err != nil {
+			return nil, err
+		}
+		if p == nil {
+			continue
+		}
+
+		boxLayer, ok := loaded[p.String()]
+		if !ok {
+			v, err := loadVagrantfileFile(p, rubyRuntime, append(opts, WithLayer(LayerBox))...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load box Vagrantfile: %w", err)
+			}
+			boxLayer = &Layer{Name: LayerBox, File: p, Vagrantfile: v}
+			loaded[p.String()] = boxLayer
+		}
+
+		machine, err := MergeLayers(append([]*Layer{boxLayer}, layers...)...)
+		if err != nil {
+			return nil, err
+		}
+		for _, mvm := range machine.ListVMs {
+			if mvm.Name == vm.Name {
+				mvm.Primary = vm.Primary
+				result.ListVMs[i] = mvm
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// loadVagrantfileFile loads the Vagrantfile using the format
+// determined by its file extension
+func loadVagrantfileFile(p path.Path, rubyRuntime parser, opts ...LoadOption) (*Vagrantfile, error) {
+	switch p.Ext() {
+	case ".hcl":
+		return LoadHCLVagrantfile(p, opts...)
+	default:
+		if rubyRuntime == nil {
+			return nil, fmt.Errorf("unable to load Ruby Vagrantfile %s without ruby runtime", p)
+		}
+		return LoadRubyVagrantfile(p, rubyRuntime, opts...)
+	}
+}
+
+// recordOrigins sets the Origins of the Vagrantfile and each of
+// its machines using the same order the layers are applied
+func recordOrigins(v *Vagrantfile, layers []*Layer) {
+	v.Origins = map[string]*Origin{}
+	for _, l := range layers {
+		if l == nil || l.Vagrantfile == nil {
+			continue
+		}
+		for k, o := range origins("vagrant", l.Vagrantfile.Vagrant, l.Name, l.File) {
+			v.Origins[k] = o
+		}
+		for k, o := range origins("ssh", l.Vagrantfile.SSH, l.Name, l.File) {
+			v.Origins[k] = o
+		}
+	}
+
+	for _, vm := range v.ListVMs {
+		vm.Origins = map[string]*Origin{}
+		for _, l := range layers {
+			if l == nil || l.Vagrantfile == nil || l.Vagrantfile.VM == nil {
+				continue
+			}
+			for k, o := range origins("", l.Vagrantfile.VM, l.Name, l.File) {
+				vm.Origins[k] = o
+			}
+		}
+		for _, l := range layers {
+			if l == nil || l.Vagrantfile == nil {
+				continue
+			}
+			for _, d := range l.Vagrantfile.DefinedVms {
+				if d.Name != vm.Name {
+					continue
+				}
+				for k, o := range origins("", d, LayerDefineVM, l.File) {
+					vm.Origins[k] = o
+				}
+			}
+		}
+	}
+}
+
+// origins returns the origin of each value set within the
+// configuration block
+func origins(prefix string, block interface{}, layer string, file path.Path) map[string]*Origin {
+	rv := reflect.ValueOf(block)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil
+	}
+
+	ranges := map[string]hcl.Range{}
+	if body, ok := rv.Elem().FieldByName("Body").Interface().(hcl.Body); ok {
+		bodyRanges(prefix, body, ranges)
+	}
+
+	result := map[string]*Origin{}
+	for _, p := range setPaths(prefix, rv) {
+		result[p] = &Origin{Layer: layer, File: file, Range: ranges[p]}
+	}
+
+	return result
+}
+
+// bodyRanges collects the ranges of the attributes and blocks
+// within the body using the same paths as setPaths. Ranges are
+// only available for native syntax bodies.
+func bodyRanges(prefix string, body hcl.Body, ranges map[string]hcl.Range) {
+	b, ok := body.(*hclsyntax.Body)
+	if !ok {
+		return
+	}
+
+	for name, attr := range b.Attributes {
+		ranges[joinPath(prefix, name)] = attr.SrcRange
+	}
+	for _, blk := range b.Blocks {
+		p := joinPath(prefix, blk.Type)
+		if _, ok := ranges[p]; !ok {
+			ranges[p] = blk.DefRange()
+		}
+		if len(blk.Labels) > 0 {
+			ranges[fmt.Sprintf("%s[%s]", p, strings.Join(blk.Labels, ","))] = blk.DefRange()
+			continue
+		}
+		bodyRanges(p, blk.Body, ranges)
+	}
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
+
+// setPaths returns the paths of the values which are set within
+// the configuration struct. Paths are built from the hcl names of
+// the fields, with labeled blocks identified by their labels.
+func setPaths(prefix string, rv reflect.Value) []string {
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var paths []string
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, kind, _ := strings.Cut(t.Field(i).Tag.Get("hcl"), ",")
+		if name == "" || kind == "label" {
+			continue
+		}
+		fv := rv.Field(i)
+		if fv.IsZero() {
+			continue
+		}
+		p := joinPath(prefix, name)
+
+		paths = append(paths, p)
+		if kind != "block" {
+			continue
+		}
+
+		switch fv.Kind() {
+		case reflect.Slice:
+			for j := 0; j < fv.Len(); j++ {
+				if labels := blockLabels(fv.Index(j)); len(labels) > 0 {
+					paths = append(paths, fmt.Sprintf("%s[%s]", p, strings.Join(labels, ",")))
+				}
+			}
+		default:
+			paths = append(paths, setPaths(p, fv)...)
+		}
+	}
+
+	return paths
+}
+
+// blockLabels returns the label values of the block struct
+func blockLabels(rv reflect.Value) []string {
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var labels []string
+	for i := 0; i < rv.NumField(); i++ {
+		if strings.HasSuffix(rv.Type().Field(i).Tag.Get("hcl"), ",label") {
+			labels = append(labels, fmt.Sprint(rv.Field(i).Interface()))
+		}
+	}
+
+	return labels
+}
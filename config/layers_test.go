@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/core"
+	"github.com/hashicorp/vagrant-plugin-sdk/core/mocks"
+	"github.com/hashicorp/vagrant-plugin-sdk/helper/path"
+)
+
+func writeVagrantfile(t *testing.T, dir, content string) path.Path {
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	p := filepath.Join(dir, HCLVagrantfileName)
+	require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+
+	return path.NewPath(p)
+}
+
+func TestLoadLayeredVagrantfile(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	boxDir := filepath.Join(dir, "box")
+	boxFile := writeVagrantfile(t, boxDir, `
+vm {
+  hostname         = "box-host"
+  guest            = "linux"
+  boot_timeout     = 600
+  communicator     = "ssh"
+}
+`)
+	home := writeVagrantfile(t, filepath.Join(dir, "home"), `
+vagrant {
+  host = "linux"
+}
+
+vm {
+  boot_timeout = 300
+}
+`)
+	project := writeVagrantfile(t, filepath.Join(dir, "project"), `
+vm {
+  box      = "hashicorp/bionic64"
+  hostname = "project-host"
+}
+
+define_vm "web" {
+  communicator = "winrm"
+}
+
+define_vm "db" {
+  ignore_box_vagrantfile = true
+}
+`)
+
+	box := mocks.NewBox(t)
+	box.On("Directory").Return(path.NewPath(boxDir), nil)
+
+	v, err := LoadLayeredVagrantfile(home, project,
+		func(vm *VM) (core.Box, error) {
+			require.Equal("hashicorp/bionic64", *vm.Box)
+			return box, nil
+		}, nil, WithStrict())
+	require.NoError(err)
+	require.Equal([]string{"web", "db"}, v.TargetNames())
+
+	web := v.ListVMs[0]
+	require.Equal("project-host", *web.Hostname)
+	require.Equal("linux", *web.Guest)
+	require.Equal(int32(300), *web.BootTimeout)
+	require.Equal("winrm", *web.Communicator)
+
+	require.Equal(LayerBox, web.Origins["guest"].Layer)
+	require.Equal(boxFile.String(), web.Origins["guest"].File.String())
+	require.Equal(LayerHome, web.Origins["boot_timeout"].Layer)
+	require.Equal(LayerProject, web.Origins["hostname"].Layer)
+	require.Equal(LayerDefineVM, web.Origins["communicator"].Layer)
+	require.Equal(project.String(), web.Origins["communicator"].File.String())
+
+	db := v.ListVMs[1]
+	require.Nil(db.Guest)
+	require.Equal(int32(300), *db.BootTimeout)
+	require.Nil(db.Origins["guest"])
+
+	require.Equal(LayerHome, v.Origins["vagrant.host"].Layer)
+}
+
+func TestMergeLayers_remain(t *testing.T) {
+	require := require.New(t)
+
+	load := func(content string) *Vagrantfile {
+		v, err := LoadVagrantfile([]byte(content), "Vagrantfile.hcl", HCL)
+		require.NoError(err)
+		return v
+	}
+	schema := &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "myplugin"}, {Type: "otherplugin"}},
+	}
+
+	// Plugin configuration only within the home layer
+	home := load(`
+myplugin {
+  enabled = true
+}
+`)
+	v, err := MergeLayers(
+		&Layer{Name: LayerHome, Vagrantfile: home},
+		&Layer{Name: LayerProject, Vagrantfile: load(`vm { box = "hashicorp/bionic64" }`)},
+	)
+	require.NoError(err)
+	content, _, diags := v.Remain.PartialContent(schema)
+	require.False(diags.HasErrors(), diags.Error())
+	require.Len(content.Blocks, 1)
+	require.Equal("myplugin", content.Blocks[0].Type)
+
+	// Plugin configuration within several layers
+	v, err = MergeLayers(
+		&Layer{Name: LayerHome, Vagrantfile: home},
+		&Layer{Name: LayerProject, Vagrantfile: load(`otherplugin { enabled = false }`)},
+	)
+	require.NoError(err)
+	content, _, diags = v.Remain.PartialContent(schema)
+	require.False(diags.HasErrors(), diags.Error())
+	require.Len(content.Blocks, 2)
+	require.Equal("myplugin", content.Blocks[0].Type)
+	require.Equal("otherplugin", content.Blocks[1].Type)
+
+	out, err := FormatVagrantfile(v)
+	require.NoError(err)
+	require.Contains(string(out), "myplugin {\n  enabled = true\n}")
+	require.Contains(string(out), "otherplugin {\n  enabled = false\n}")
+}
+
+func TestBoxVagrantfile(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	box := mocks.NewBox(t)
+	box.On("Directory").Return(path.NewPath(dir), nil)
+
+	p, err := BoxVagrantfile(box)
+	require.NoError(err)
+	require.Nil(p)
+
+	require.NoError(os.WriteFile(filepath.Join(dir, "Vagrantfile"), []byte(""), 0644))
+	p, err = BoxVagrantfile(box)
+	require.NoError(err)
+	require.Equal(filepath.Join(dir, "Vagrantfile"), p.String())
+}
@@ -8,14 +8,20 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
 
-	"github.com/fatih/structs" // TODO(spox): this is unmaintained - look to vendor internally
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	hcljson "github.com/hashicorp/hcl/v2/json"
 	"github.com/mitchellh/mapstructure"
 
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/config/network"
+	"github.com/hashicorp/vagrant-plugin-sdk/config/provider"
+	"github.com/hashicorp/vagrant-plugin-sdk/config/provisioner"
 	"github.com/hashicorp/vagrant-plugin-sdk/helper/path"
 	"github.com/hashicorp/vagrant-plugin-sdk/internal-shared/dynamic"
 	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
@@ -33,83 +39,265 @@ const (
 	RUBY
 )
 
+// DefaultVMName is the name given to the global vm block
+// when no label is provided in an HCL Vagrantfile
+const DefaultVMName = "default"
+
 var Mappers []*argmapper.Func
 
 // Load Vagrant configuration using a Ruby based Vagrantfile
 func LoadRubyVagrantfile(
 	p path.Path, // path to Ruby Vagrantfile
 	rubyRuntime parser, // ruby runtime plugin
+	opts ...LoadOption,
 ) (*Vagrantfile, error) {
 	s, err := rubyRuntime.ParseVagrantfile(p.String())
 	if err != nil {
 		return nil, err
 	}
 
-	return LoadVagrantfile(s.Json, p.String(), JSON)
+	return LoadVagrantfile(s.Json, p.String(), JSON, opts...)
 }
 
 // Load Vagrant configuration using an HCL based Vagrantfile
 func LoadHCLVagrantfile(
 	p path.Path, // path to HCL Vagrantfile
+	opts ...LoadOption,
 ) (*Vagrantfile, error) {
 	f, err := os.Open(p.String())
 	if err != nil {
 		return nil, err
 	}
+	defer f.Close()
 
 	c, err := io.ReadAll(f)
 	if err != nil {
 		return nil, err
 	}
 
-	return LoadVagrantfile(c, p.String(), HCL)
+	return LoadVagrantfile(c, p.String(), HCL, opts...)
 }
 
-// Load a Vagrantfile
+// Load a Vagrantfile. Errors encountered while decoding the
+// Vagrantfile are ignored unless the WithStrict option is
+// provided. Any error returned due to invalid configuration
+// will be a *Diagnostics.
 func LoadVagrantfile(
 	content []byte, // Vagrantfile content
 	loc string, // path of file imported
 	kind VagrantfileFormat, // type of content (JSON or HCL file)
+	opts ...LoadOption,
 ) (*Vagrantfile, error) {
+	cfg := &loadConfig{layer: LayerProject}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	diags := cfg.diags
+	if diags == nil {
+		diags = &Diagnostics{}
+	}
+	if diags.Files == nil {
+		diags.Files = map[string]*hcl.File{}
+	}
+
 	var f *hcl.File
 	var d hcl.Diagnostics
 	switch kind {
 	case JSON:
 		f, d = hcljson.Parse(content, loc)
 	case HCL:
-		return nil, fmt.Errorf("not implemented")
+		f, d = hclsyntax.ParseConfig(content, loc, hcl.Pos{Line: 1, Column: 1})
 	default:
 		return nil, fmt.Errorf("invalid Vagrantfile format defined")
 	}
+	if f != nil {
+		diags.Files[loc] = f
+	}
+	diags.Append(d...)
 	if d.HasErrors() {
-		return nil, d
+		return nil, diags
 	}
 
-	v := &Vagrantfile{}
-	d = gohcl.DecodeBody(f.Body, &hcl.EvalContext{}, v)
-	if d.HasErrors() {
-		// we never error!
-		// return nil, d
+	ctx := &hcl.EvalContext{}
+	body := f.Body
+	if kind == HCL {
+		setDefaultVMLabel(f.Body)
+
+		ctx, body, d = evalContext(f.Body, filepath.Dir(loc), cfg)
+		diags.Append(d...)
+		if d.HasErrors() {
+			return nil, diags
+		}
 	}
 
+	v := &Vagrantfile{EvalContext: ctx}
+	d = gohcl.DecodeBody(body, ctx, v)
+	d = d.Extend(v.decodeVMs(ctx))
+	if kind == HCL {
+		sev := hcl.DiagWarning
+		if cfg.strict {
+			sev = hcl.DiagError
+		}
+		d = d.Extend(unknownArguments(v, sev))
+	}
+	if err := v.Finalize(); err != nil {
+		d = d.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid machine definitions",
+			Detail:   err.Error(),
+		})
+	}
+	diags.Append(d...)
+	if d.HasErrors() && cfg.strict {
+		return nil, diags
+	}
+
+	recordOrigins(v, []*Layer{{Name: cfg.layer, File: path.NewPath(loc), Vagrantfile: v}})
+
 	return v, nil
 }
 
+// Unknown blocks are retained within the Remain bodies so plugins can
+// decode them. Any arguments remaining within the core blocks are
+// most likely typos, so generate diagnostics for them.
+func unknownArguments(v *Vagrantfile, sev hcl.DiagnosticSeverity) hcl.Diagnostics {
+	bodies := []hcl.Body{v.Remain}
+	if v.Vagrant != nil {
+		bodies = append(bodies, v.Vagrant.Remain)
+	}
+	if v.SSH != nil {
+		bodies = append(bodies, v.SSH.Remain)
+	}
+	if v.VM != nil {
+		bodies = append(bodies, v.VM.Remain)
+	}
+	for _, vm := range v.DefinedVms {
+		bodies = append(bodies, vm.Remain)
+	}
+
+	var diags hcl.Diagnostics
+	for _, body := range bodies {
+		b, ok := body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		// Blocks within the body will generate errors
+		// which can be ignored
+		attrs, _ := b.JustAttributes()
+		list := make([]*hcl.Attribute, 0, len(attrs))
+		for _, attr := range attrs {
+			list = append(list, attr)
+		}
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].Range.Start.Byte < list[j].Range.Start.Byte
+		})
+
+		for _, attr := range list {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: sev,
+				Summary:  "Unsupported argument",
+				Detail:   fmt.Sprintf("An argument named %q is not expected here.", attr.Name),
+				Subject:  attr.NameRange.Ptr(),
+			})
+		}
+	}
+
+	return diags
+}
+
+// Decode the typed configuration of blocks within the vm
+// and define_vm blocks
+func (v *Vagrantfile) decodeVMs(ctx *hcl.EvalContext) hcl.Diagnostics {
+	vms := v.DefinedVms
+	if v.VM != nil {
+		vms = append([]*VM{v.VM}, vms...)
+	}
+
+	var diags hcl.Diagnostics
+	for _, vm := range vms {
+		for _, n := range vm.Networks {
+			diags = diags.Extend(n.decode(ctx))
+		}
+		for _, p := range vm.Providers {
+			diags = diags.Extend(p.decode(ctx))
+		}
+		for _, p := range vm.Provisioners {
+			diags = diags.Extend(p.decode(ctx))
+		}
+	}
+
+	return diags
+}
+
+// Kinds of networks which are not known are left
+// within the body for plugins to decode
+func (n *Network) decode(ctx *hcl.EvalContext) hcl.Diagnostics {
+	switch n.Kind {
+	case "forwarded_port":
+		n.ForwardedPort = &network.ForwardedPort{}
+		return gohcl.DecodeBody(n.Remain, ctx, n.ForwardedPort)
+	case "private_network", "public_network":
+		n.Network = &network.Network{}
+		return gohcl.DecodeBody(n.Remain, ctx, n.Network)
+	}
+
+	return nil
+}
+
+// Providers which are not known are left within
+// the body for plugins to decode
+func (p *Provider) decode(ctx *hcl.EvalContext) hcl.Diagnostics {
+	switch p.Name {
+	case "virtualbox":
+		p.VirtualBox = &provider.VirtualBox{}
+		return gohcl.DecodeBody(p.Remain, ctx, p.VirtualBox)
+	}
+
+	return nil
+}
+
+// Kinds of provisioners which are not known are left
+// within the body for plugins to decode
+func (p *Provisioner) decode(ctx *hcl.EvalContext) hcl.Diagnostics {
+	switch p.Kind {
+	case "file":
+		p.File = &provisioner.File{}
+		return gohcl.DecodeBody(p.Remain, ctx, p.File)
+	case "shell":
+		p.Shell = &provisioner.Shell{}
+		return gohcl.DecodeBody(p.Remain, ctx, p.Shell)
+	}
+
+	return nil
+}
+
+// The global vm block within an HCL Vagrantfile does not
+// require a label. When the label is not provided the
+// default name is used so it decodes the same as a
+// define_vm block.
+func setDefaultVMLabel(body hcl.Body) {
+	b, ok := body.(*hclsyntax.Body)
+	if !ok {
+		return
+	}
+
+	for _, blk := range b.Blocks {
+		if blk.Type == "vm" && len(blk.Labels) == 0 {
+			blk.Labels = []string{DefaultVMName}
+			blk.LabelRanges = []hcl.Range{blk.TypeRange}
+		}
+	}
+}
+
 // Decode a proto encoded Vagrantfile
 func DecodeVagrantfile(
 	data *vagrant_plugin_sdk.Args_ConfigData,
 	args ...argmapper.Arg,
 ) (v *Vagrantfile, err error) {
-	args = append(args, argmapper.ConverterFunc(Mappers...))
-	mapped, err := dynamic.Map(data,
-		(*map[string]interface{})(nil),
-		args...,
-	)
-	if err != nil {
-		return
-	}
 	v = &Vagrantfile{}
-	err = mapstructure.Decode(mapped, v)
+	err = DecodeConfiguration(data, v, args...)
 
 	return
 }
@@ -119,9 +307,22 @@ func EncodeVagrantfile(
 	v *Vagrantfile,
 	args ...argmapper.Arg,
 ) (*vagrant_plugin_sdk.Args_ConfigData, error) {
+	// Convert the Vagrantfile to generic values. This
+	// excludes the HCL bodies and evaluation context.
+	content, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]interface{}{}
+	if err = json.Unmarshal(content, &data); err != nil {
+		return nil, err
+	}
+	for k, val := range data {
+		data[k] = hashValue(val)
+	}
+
 	args = append(args, argmapper.ConverterFunc(Mappers...))
-	mapped := structs.New(v)
-	raw, err := dynamic.Map(mapped,
+	raw, err := dynamic.Map(&component.ConfigData{Data: data},
 		(**vagrant_plugin_sdk.Args_ConfigData)(nil),
 		args...,
 	)
@@ -139,17 +340,37 @@ func DecodeConfiguration(
 ) (err error) {
 	args = append(args, argmapper.ConverterFunc(Mappers...))
 	mapped, err := dynamic.Map(data,
-		(*map[string]interface{})(nil),
+		(**component.ConfigData)(nil),
 		args...,
 	)
 	if err != nil {
 		return
 	}
-	err = mapstructure.Decode(mapped, i)
+	err = mapstructure.Decode(mapped.(*component.ConfigData).Data, i)
 
 	return
 }
 
+// Nested values within a hash must use interface keys
+// so they can be converted to protos
+func hashValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		result := make(map[interface{}]interface{}, len(val))
+		for k, item := range val {
+			result[k] = hashValue(item)
+		}
+		return result
+	case []interface{}:
+		for i, item := range val {
+			val[i] = hashValue(item)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
 // Restore an encoded Vagrantfile
 func RestoreVagrantfile(
 	s []byte,
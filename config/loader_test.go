@@ -0,0 +1,261 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/vagrant-plugin-sdk/helper/path"
+	"github.com/stretchr/testify/require"
+)
+
+const testHCLVagrantfile = `
+vagrant {
+  sensitive = ["secret"]
+
+  plugins "vagrant-example" {
+    version = "~> 1.0"
+  }
+}
+
+ssh {
+  connect_timeout = 30
+  forward_agent   = true
+}
+
+communicator "winrm" {
+  port = 5986
+}
+
+vm {
+  box      = "hashicorp/bionic64"
+  hostname = "example"
+
+  provider "libvirt" {
+    memory = 1024
+  }
+
+  trigger "up" {
+    info = "starting"
+  }
+}
+
+define_vm "web" {
+  box     = "hashicorp/focal64"
+  primary = true
+}
+
+define_vm "db" {
+  autostart = false
+}
+`
+
+func TestLoadVagrantfile_HCL(t *testing.T) {
+	require := require.New(t)
+
+	v, err := LoadVagrantfile([]byte(testHCLVagrantfile), "Vagrantfile.hcl", HCL)
+	require.NoError(err)
+
+	require.NotNil(v.Vagrant)
+	require.Equal([]string{"secret"}, v.Vagrant.Sensitive)
+	require.Len(v.Vagrant.Plugins, 1)
+	require.Equal("vagrant-example", v.Vagrant.Plugins[0].Name)
+	require.Equal("~> 1.0", *v.Vagrant.Plugins[0].Version)
+
+	require.NotNil(v.SSH)
+	require.Equal(int32(30), *v.SSH.ConnectTimeout)
+
+	require.Len(v.Communicators, 1)
+	require.Equal("winrm", v.Communicators[0].Name)
+
+	require.NotNil(v.VM)
+	require.Equal(DefaultVMName, v.VM.Name)
+	require.Equal("hashicorp/bionic64", *v.VM.Box)
+	require.Equal("example", *v.VM.Hostname)
+
+	require.Len(v.DefinedVms, 2)
+	require.Equal("web", v.DefinedVms[0].Name)
+	require.Equal("hashicorp/focal64", *v.DefinedVms[0].Box)
+	require.True(*v.DefinedVms[0].Primary)
+	require.Equal("db", v.DefinedVms[1].Name)
+	require.False(*v.DefinedVms[1].AutoStart)
+}
+
+func TestLoadVagrantfile_HCLRemain(t *testing.T) {
+	require := require.New(t)
+
+	v, err := LoadVagrantfile([]byte(testHCLVagrantfile), "Vagrantfile.hcl", HCL)
+	require.NoError(err)
+
+	// Plugins must be able to decode their own blocks
+	var vmExtra struct {
+		Triggers []struct {
+			Name string `hcl:"name,label"`
+			Info string `hcl:"info"`
+		} `hcl:"trigger,block"`
+	}
+	d := gohcl.DecodeBody(v.VM.Remain, &hcl.EvalContext{}, &vmExtra)
+	require.False(d.HasErrors(), d.Error())
+	require.Len(vmExtra.Triggers, 1)
+	require.Equal("up", vmExtra.Triggers[0].Name)
+	require.Equal("starting", vmExtra.Triggers[0].Info)
+
+	var providerExtra struct {
+		Memory int `hcl:"memory"`
+	}
+	require.Len(v.VM.Providers, 1)
+	require.Nil(v.VM.Providers[0].VirtualBox)
+	d = gohcl.DecodeBody(v.VM.Providers[0].Remain, &hcl.EvalContext{}, &providerExtra)
+	require.False(d.HasErrors(), d.Error())
+	require.Equal(1024, providerExtra.Memory)
+
+	var sshExtra struct {
+		ForwardAgent bool `hcl:"forward_agent"`
+	}
+	d = gohcl.DecodeBody(v.SSH.Remain, &hcl.EvalContext{}, &sshExtra)
+	require.False(d.HasErrors(), d.Error())
+	require.True(sshExtra.ForwardAgent)
+
+	var commExtra struct {
+		Port int `hcl:"port"`
+	}
+	d = gohcl.DecodeBody(v.Communicators[0].Remain, &hcl.EvalContext{}, &commExtra)
+	require.False(d.HasErrors(), d.Error())
+	require.Equal(5986, commExtra.Port)
+}
+
+func TestLoadVagrantfile_HCLSyntaxError(t *testing.T) {
+	require := require.New(t)
+
+	_, err := LoadVagrantfile([]byte(`vm {`), "Vagrantfile.hcl", HCL)
+	require.Error(err)
+}
+
+func TestLoadHCLVagrantfile(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := os.MkdirTemp("", "configtest")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	vagrantfilePath := filepath.Join(dir, "Vagrantfile.hcl")
+	require.NoError(os.WriteFile(vagrantfilePath, []byte(testHCLVagrantfile), 0644))
+
+	v, err := LoadHCLVagrantfile(path.NewPath(vagrantfilePath))
+	require.NoError(err)
+	require.Len(v.DefinedVms, 2)
+}
+
+const testVMHCLVagrantfile = `
+vm {
+  box = "hashicorp/bionic64"
+
+  network "forwarded_port" {
+    guest = 80
+    host  = 8080
+  }
+
+  network "private_network" {
+    ip = "192.168.33.10"
+  }
+
+  provider "virtualbox" {
+    memory    = 2048
+    cpus      = 2
+    customize = [["modifyvm", ":id", "--vram", "16"]]
+  }
+
+  provisioner "shell" {
+    inline     = "echo hello"
+    keep_color = true
+    env        = { NAME = "vagrant" }
+  }
+
+  provisioner "file" {
+    source      = "config.txt"
+    destination = "/tmp/config.txt"
+  }
+
+  provisioner "ansible" {
+    playbook = "site.yml"
+  }
+
+  synced_folder "." "/vagrant" {
+    disabled = true
+  }
+
+  disk "extra" {
+    size = "10GB"
+  }
+
+  cloud_init {
+    content_type = "text/cloud-config"
+    path         = "user-data.yml"
+  }
+
+  usable_port_range {
+    start = 2200
+    end   = 2250
+  }
+}
+`
+
+func TestLoadVagrantfile_VM(t *testing.T) {
+	require := require.New(t)
+
+	v, err := LoadVagrantfile([]byte(testVMHCLVagrantfile), "Vagrantfile.hcl", HCL, WithStrict())
+	require.NoError(err)
+
+	vm := v.VM
+	require.Len(vm.Networks, 2)
+	require.Equal("forwarded_port", vm.Networks[0].Kind)
+	require.Equal(int32(80), vm.Networks[0].ForwardedPort.Guest)
+	require.Equal(int32(8080), vm.Networks[0].ForwardedPort.Host)
+	require.Equal("192.168.33.10", vm.Networks[1].Network.IP)
+
+	require.Len(vm.Providers, 1)
+	require.Equal(int32(2048), vm.Providers[0].VirtualBox.Memory)
+	require.Equal(int32(2), vm.Providers[0].VirtualBox.CPUs)
+	require.Equal([][]string{{"modifyvm", ":id", "--vram", "16"}}, vm.Providers[0].VirtualBox.Customize)
+
+	require.Len(vm.Provisioners, 3)
+	require.Equal("echo hello", vm.Provisioners[0].Shell.Inline)
+	require.True(vm.Provisioners[0].Shell.KeepColor)
+	require.Equal(map[string]string{"NAME": "vagrant"}, vm.Provisioners[0].Shell.Env)
+	require.Equal("/tmp/config.txt", vm.Provisioners[1].File.Destination)
+	require.Nil(vm.Provisioners[2].Shell)
+	require.Nil(vm.Provisioners[2].File)
+
+	require.Len(vm.SyncedFolders, 1)
+	require.Equal(".", vm.SyncedFolders[0].Source)
+	require.Equal("/vagrant", vm.SyncedFolders[0].Destination)
+	require.True(*vm.SyncedFolders[0].Disabled)
+
+	require.Len(vm.Disks, 1)
+	require.Equal("10GB", *vm.Disks[0].Size)
+	require.Equal("user-data.yml", *vm.CloudInit.Path)
+	require.Equal(int32(2200), vm.UsablePortRange.Start)
+	require.Equal(int32(2250), vm.UsablePortRange.End)
+
+	require.Len(vm.Target().Providers, 1)
+}
+
+func TestLoadVagrantfile_VMInvalid(t *testing.T) {
+	require := require.New(t)
+
+	content := []byte(`
+vm {
+  provider "virtualbox" {
+    memroy = 2048
+  }
+}
+`)
+	_, err := LoadVagrantfile(content, "Vagrantfile.hcl", HCL, WithStrict())
+	require.Error(err)
+	require.Contains(err.Error(), "memroy")
+}
@@ -0,0 +1,650 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package merge implements Vagrant's configuration merge rules
+// for configuration structures and component.ConfigData values.
+//
+// By default scalar values in the overlay replace values in the
+// base, lists are appended with duplicate entries removed, and
+// maps and structs are merged deeply. The behavior can be changed
+// per field using the merge struct tag:
+//
+//	Name      string            `merge:"replace"`
+//	Mounts    []*Mount          `merge:"append,key=destination"`
+//	Env       map[string]string `merge:"replace"`
+//	Internal  string            `merge:"-"`
+//
+// The key option of the append strategy identifies entries which
+// describe the same item. The overlay entry is merged into the
+// base entry instead of being appended.
+//
+// When merging structs, a zero scalar value (false, 0, "") in the
+// overlay can not be told apart from an unset value, so it never
+// replaces the base value. Fields which must be able to override
+// the base with a zero value should be pointers, like *bool. Within
+// configuration data any value present in the overlay is applied,
+// including zero values.
+//
+// The define_vm blocks of a Vagrantfile are overlays of the global
+// vm block. DefinedVMs applies them to produce the configuration
+// of each machine.
+//
+// A config plugin can implement its MergeFunc with Structs:
+//
+//	func (c *Config) MergeFunc() interface{} { return c.Merge }
+//
+//	func (c *Config) Merge(input struct {
+//		argmapper.Struct
+//		Base    *Config
+//		Overlay *Config
+//	}) (*Config, error) {
+//		return merge.Structs(input.Base, input.Overlay)
+//	}
+package merge
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+)
+
+// Strategy is the method used to merge a value
+type Strategy int
+
+const (
+	Default Strategy = iota // Strategy based on the type of the value
+	Replace                 // Overlay value replaces the base value
+	Append                  // Overlay list is appended to the base list
+	Ignore                  // Base value is always kept
+)
+
+// TagName is the struct tag used to configure merging
+const TagName = "merge"
+
+// rule is the merge configuration of a single field
+type rule struct {
+	strategy Strategy
+	key      string
+}
+
+// parseRule parses the merge tag of a struct field
+func parseRule(f reflect.StructField) (rule, error) {
+	tag, ok := f.Tag.Lookup(TagName)
+	if !ok {
+		return rule{}, nil
+	}
+
+	parts := strings.Split(tag, ",")
+	var r rule
+	switch parts[0] {
+	case "":
+		r.strategy = Default
+	case "replace":
+		r.strategy = Replace
+	case "append":
+		r.strategy = Append
+	case "-":
+		r.strategy = Ignore
+	default:
+		return r, fmt.Errorf("field %s: unknown merge strategy %q", f.Name, parts[0])
+	}
+
+	for _, opt := range parts[1:] {
+		k, v, _ := strings.Cut(opt, "=")
+		switch k {
+		case "key":
+			if v == "" {
+				return r, fmt.Errorf("field %s: merge key requires a value", f.Name)
+			}
+			r.key = v
+		default:
+			return r, fmt.Errorf("field %s: unknown merge option %q", f.Name, opt)
+		}
+	}
+
+	return r, nil
+}
+
+// fieldNames returns the names a field may be referenced by:
+// the Go field name and its hcl, json and mapstructure names
+func fieldNames(f reflect.StructField) []string {
+	names := []string{f.Name}
+	for _, t := range []string{"hcl", "json", "mapstructure"} {
+		n, _, _ := strings.Cut(f.Tag.Get(t), ",")
+		if n != "" && n != "-" {
+			names = append(names, n)
+		}
+	}
+
+	return names
+}
+
+// findField locates the struct field matching the given name
+func findField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		for _, n := range fieldNames(f) {
+			if strings.EqualFold(n, name) {
+				return f, true
+			}
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// Structs merges the overlay configuration into the base
+// configuration. A new value is returned and neither the
+// base or overlay are modified.
+func Structs[T any](base, overlay *T) (*T, error) {
+	if base == nil && overlay == nil {
+		return nil, nil
+	}
+
+	var zero T
+	if base == nil {
+		base = &zero
+	}
+	if overlay == nil {
+		overlay = &zero
+	}
+
+	result, err := value(reflect.ValueOf(base).Elem(), reflect.ValueOf(overlay).Elem(), rule{})
+	if err != nil {
+		return nil, err
+	}
+
+	out := new(T)
+	reflect.ValueOf(out).Elem().Set(result)
+
+	return out, nil
+}
+
+// value merges two values of the same type
+func value(base, overlay reflect.Value, r rule) (reflect.Value, error) {
+	if r.strategy == Ignore {
+		return base, nil
+	}
+
+	switch base.Kind() {
+	case reflect.Ptr:
+		if overlay.IsNil() {
+			return base, nil
+		}
+		if base.IsNil() || r.strategy == Replace ||
+			base.Elem().Kind() != reflect.Struct {
+			return overlay, nil
+		}
+		v, err := value(base.Elem(), overlay.Elem(), r)
+		if err != nil {
+			return v, err
+		}
+		result := reflect.New(v.Type())
+		result.Elem().Set(v)
+		return result, nil
+	case reflect.Struct:
+		if r.strategy == Replace {
+			return overlay, nil
+		}
+		return structValue(base, overlay)
+	case reflect.Map:
+		if overlay.Len() == 0 {
+			return base, nil
+		}
+		if base.Len() == 0 || r.strategy == Replace {
+			return overlay, nil
+		}
+		return mapValue(base, overlay)
+	case reflect.Slice:
+		if overlay.Len() == 0 {
+			return base, nil
+		}
+		if r.strategy == Replace {
+			return overlay, nil
+		}
+		return sliceValue(base, overlay, r)
+	case reflect.Interface:
+		if overlay.IsNil() {
+			return base, nil
+		}
+		// Only collections held within interfaces are merged
+		if base.IsNil() || r.strategy == Replace ||
+			base.Elem().Type() != overlay.Elem().Type() ||
+			(base.Elem().Kind() != reflect.Map && base.Elem().Kind() != reflect.Slice) {
+			return overlay, nil
+		}
+		v, err := value(base.Elem(), overlay.Elem(), r)
+		if err != nil {
+			return v, err
+		}
+		result := reflect.New(base.Type()).Elem()
+		result.Set(v)
+		return result, nil
+	default:
+		// Zero values are indistinguishable from unset values
+		// so only non-zero overlay values are applied
+		if overlay.IsZero() {
+			return base, nil
+		}
+		return overlay, nil
+	}
+}
+
+func structValue(base, overlay reflect.Value) (reflect.Value, error) {
+	t := base.Type()
+	result := reflect.New(t).Elem()
+	result.Set(base)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		r, err := parseRule(f)
+		if err != nil {
+			return result, err
+		}
+
+		v, err := value(base.Field(i), overlay.Field(i), r)
+		if err != nil {
+			return result, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		result.Field(i).Set(v)
+	}
+
+	return result, nil
+}
+
+func mapValue(base, overlay reflect.Value) (reflect.Value, error) {
+	result := reflect.MakeMapWithSize(base.Type(), base.Len())
+	iter := base.MapRange()
+	for iter.Next() {
+		result.SetMapIndex(iter.Key(), iter.Value())
+	}
+
+	iter = overlay.MapRange()
+	for iter.Next() {
+		existing := result.MapIndex(iter.Key())
+		if !existing.IsValid() {
+			result.SetMapIndex(iter.Key(), iter.Value())
+			continue
+		}
+
+		v, err := value(existing, iter.Value(), rule{})
+		if err != nil {
+			return result, err
+		}
+		result.SetMapIndex(iter.Key(), v)
+	}
+
+	return result, nil
+}
+
+func sliceValue(base, overlay reflect.Value, r rule) (reflect.Value, error) {
+	result := reflect.MakeSlice(base.Type(), 0, base.Len()+overlay.Len())
+	result = reflect.AppendSlice(result, base)
+
+	for i := 0; i < overlay.Len(); i++ {
+		item := overlay.Index(i)
+		idx := -1
+		for j := 0; j < result.Len(); j++ {
+			if r.key != "" {
+				if sameKey(result.Index(j), item, r.key) {
+					idx = j
+					break
+				}
+			} else if reflect.DeepEqual(result.Index(j).Interface(), item.Interface()) {
+				idx = j
+				break
+			}
+		}
+
+		if idx < 0 {
+			result = reflect.Append(result, item)
+			continue
+		}
+
+		// Items matched by key are merged
+		if r.key != "" {
+			v, err := value(result.Index(idx), item, rule{})
+			if err != nil {
+				return result, err
+			}
+			result.Index(idx).Set(v)
+		}
+	}
+
+	return result, nil
+}
+
+// sameKey checks if two list entries have matching key values
+func sameKey(a, b reflect.Value, key string) bool {
+	ak, ok := keyValue(a, key)
+	if !ok {
+		return false
+	}
+	bk, ok := keyValue(b, key)
+	if !ok {
+		return false
+	}
+
+	return reflect.DeepEqual(ak, bk)
+}
+
+// keyValue extracts the value of the key from a list entry
+func keyValue(v reflect.Value, key string) (interface{}, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		f, ok := findField(v.Type(), key)
+		if !ok {
+			return nil, false
+		}
+		fv := v.FieldByIndex(f.Index)
+		for fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				return nil, false
+			}
+			fv = fv.Elem()
+		}
+		return fv.Interface(), true
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if k, ok := iter.Key().Interface().(string); ok && strings.EqualFold(k, key) {
+				return iter.Value().Interface(), true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// Names of the Vagrantfile configuration data used to resolve
+// machines
+const (
+	vmKey         = "vm"
+	defineVMKey   = "define_vm"
+	vmNameKey     = "name"
+	defaultVMName = "default"
+)
+
+// Machine is the configuration data of a single machine
+type Machine struct {
+	Name string
+	Data *component.ConfigData
+}
+
+// DefinedVMs applies each define_vm overlay within the Vagrantfile
+// configuration data onto the global vm and returns the machines in
+// the order they are defined. Overlays with the same name are applied
+// in order onto the same machine. When no define_vm overlays exist
+// the global vm defines a single machine, named by the vm or default.
+// The structure provided using WithStruct describes the vm.
+func DefinedVMs(vagrantfile *component.ConfigData, opts ...Option) ([]*Machine, error) {
+	m := &dataMerger{}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if vagrantfile == nil {
+		vagrantfile = &component.ConfigData{}
+	}
+
+	global, ok := vagrantfile.Data[vmKey].(map[string]interface{})
+	if !ok && vagrantfile.Data[vmKey] != nil {
+		return nil, fmt.Errorf("%s: expected map but found %T", vmKey, vagrantfile.Data[vmKey])
+	}
+
+	var defined []interface{}
+	switch d := vagrantfile.Data[defineVMKey].(type) {
+	case nil:
+	case []interface{}:
+		defined = d
+	default:
+		return nil, fmt.Errorf("%s: expected list but found %T", defineVMKey, d)
+	}
+
+	if len(defined) == 0 {
+		name := defaultVMName
+		if n, ok := keyValue(reflect.ValueOf(global), vmNameKey); ok {
+			if s, ok := n.(string); ok && s != "" {
+				name = s
+			}
+		}
+		defined = []interface{}{map[string]interface{}{vmNameKey: name}}
+	}
+
+	var result []*Machine
+	machines := map[string]*Machine{}
+	for i, d := range defined {
+		overlay, ok := d.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s %d: expected map but found %T", defineVMKey, i, d)
+		}
+		n, _ := keyValue(reflect.ValueOf(overlay), vmNameKey)
+		name, ok := n.(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%s %d: missing name", defineVMKey, i)
+		}
+
+		machine, ok := machines[name]
+		if !ok {
+			machine = &Machine{
+				Name: name,
+				Data: &component.ConfigData{Source: vagrantfile.Source, Data: global},
+			}
+			machines[name] = machine
+			result = append(result, machine)
+		}
+
+		data, err := m.merge(machine.Data.Data, overlay, m.typ, rule{})
+		if err != nil {
+			return nil, fmt.Errorf("%s %q: %w", defineVMKey, name, err)
+		}
+		vm, _ := data.(map[string]interface{})
+		machine.Data = &component.ConfigData{Source: vagrantfile.Source, Data: vm}
+	}
+
+	return result, nil
+}
+
+// Option modifies how configuration data is merged
+type Option func(*dataMerger)
+
+type dataMerger struct {
+	typ reflect.Type
+}
+
+// WithStruct provides the configuration structure that describes
+// the configuration data. Merge tags on the structure fields are
+// used when merging the data.
+func WithStruct(v interface{}) Option {
+	return func(m *dataMerger) {
+		m.typ = structType(reflect.TypeOf(v))
+	}
+}
+
+// Data merges the overlay configuration data into the base
+// configuration data. A new value is returned and neither the
+// base or overlay are modified.
+func Data(base, overlay *component.ConfigData, opts ...Option) (*component.ConfigData, error) {
+	m := &dataMerger{}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if base == nil {
+		base = &component.ConfigData{}
+	}
+	if overlay == nil {
+		overlay = &component.ConfigData{}
+	}
+
+	result := &component.ConfigData{Source: base.Source}
+	if overlay.Source != "" {
+		result.Source = overlay.Source
+	}
+
+	data, err := m.merge(base.Data, overlay.Data, m.typ, rule{})
+	if err != nil {
+		return nil, err
+	}
+	if data != nil {
+		result.Data = data.(map[string]interface{})
+	}
+
+	// Values set by the overlay take the provenance of the overlay
+	if len(base.Provenance) > 0 || len(overlay.Provenance) > 0 {
+		result.Provenance = map[string]*component.Provenance{}
+		for k, p := range base.Provenance {
+			result.Provenance[k] = p
+		}
+		for k, p := range overlay.Provenance {
+			result.Provenance[k] = p
+		}
+	}
+
+	return result, nil
+}
+
+// structType returns the struct type, if any, for the given type
+// with pointers, slices and maps removed
+func structType(t reflect.Type) reflect.Type {
+	for t != nil {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			return t
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// merge merges configuration data values. The struct type, if
+// provided, describes the values when they are maps.
+func (m *dataMerger) merge(base, overlay interface{}, t reflect.Type, r rule) (interface{}, error) {
+	if r.strategy == Ignore || overlay == nil {
+		return base, nil
+	}
+	if base == nil || r.strategy == Replace {
+		return overlay, nil
+	}
+
+	switch b := base.(type) {
+	case map[string]interface{}:
+		o, ok := overlay.(map[string]interface{})
+		if !ok {
+			return overlay, nil
+		}
+		result := make(map[string]interface{}, len(b))
+		for k, v := range b {
+			result[k] = v
+		}
+		for k, v := range o {
+			ft, fr, err := m.field(t, k)
+			if err != nil {
+				return nil, err
+			}
+			if result[k], err = m.merge(result[k], v, ft, fr); err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+		}
+		return result, nil
+	case map[interface{}]interface{}:
+		o, ok := overlay.(map[interface{}]interface{})
+		if !ok {
+			return overlay, nil
+		}
+		result := make(map[interface{}]interface{}, len(b))
+		for k, v := range b {
+			result[k] = v
+		}
+		for k, v := range o {
+			var ft reflect.Type
+			fr := rule{}
+			if ks, ok := k.(string); ok {
+				var err error
+				if ft, fr, err = m.field(t, ks); err != nil {
+					return nil, err
+				}
+			}
+			var err error
+			if result[k], err = m.merge(result[k], v, ft, fr); err != nil {
+				return nil, fmt.Errorf("%v: %w", k, err)
+			}
+		}
+		return result, nil
+	case []interface{}:
+		o, ok := overlay.([]interface{})
+		if !ok {
+			return overlay, nil
+		}
+		return m.list(b, o, t, r)
+	default:
+		return overlay, nil
+	}
+}
+
+// field returns the struct type and merge rule for a field
+func (m *dataMerger) field(t reflect.Type, name string) (reflect.Type, rule, error) {
+	if t == nil {
+		return nil, rule{}, nil
+	}
+
+	f, ok := findField(t, name)
+	if !ok {
+		return nil, rule{}, nil
+	}
+
+	r, err := parseRule(f)
+
+	return structType(f.Type), r, err
+}
+
+func (m *dataMerger) list(base, overlay []interface{}, t reflect.Type, r rule) (interface{}, error) {
+	result := make([]interface{}, 0, len(base)+len(overlay))
+	result = append(result, base...)
+
+	for _, item := range overlay {
+		idx := -1
+		for j, existing := range result {
+			if r.key != "" {
+				if sameKey(reflect.ValueOf(existing), reflect.ValueOf(item), r.key) {
+					idx = j
+					break
+				}
+			} else if reflect.DeepEqual(existing, item) {
+				idx = j
+				break
+			}
+		}
+
+		if idx < 0 {
+			result = append(result, item)
+			continue
+		}
+
+		if r.key != "" {
+			v, err := m.merge(result[idx], item, t, rule{})
+			if err != nil {
+				return nil, err
+			}
+			result[idx] = v
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,263 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package merge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+)
+
+type testMount struct {
+	Destination string `json:"destination"`
+	Owner       *string
+	Options     []string
+}
+
+type testConfig struct {
+	Name     string
+	Memory   *int32
+	Tags     []string
+	Env      map[string]string
+	Labels   map[string]string `merge:"replace"`
+	Mounts   []*testMount      `merge:"append,key=destination"`
+	Internal string            `merge:"-"`
+	Nested   *testConfig
+}
+
+func strPtr(s string) *string { return &s }
+func intPtr(i int32) *int32   { return &i }
+
+func TestStructs(t *testing.T) {
+	require := require.New(t)
+
+	base := &testConfig{
+		Name:     "base",
+		Memory:   intPtr(512),
+		Tags:     []string{"a", "b"},
+		Env:      map[string]string{"A": "1", "B": "2"},
+		Labels:   map[string]string{"x": "1"},
+		Mounts:   []*testMount{{Destination: "/vagrant", Owner: strPtr("vagrant"), Options: []string{"ro"}}},
+		Internal: "keep",
+		Nested:   &testConfig{Name: "inner", Memory: intPtr(1)},
+	}
+	overlay := &testConfig{
+		Memory:   intPtr(1024),
+		Tags:     []string{"b", "c"},
+		Env:      map[string]string{"B": "3"},
+		Labels:   map[string]string{"y": "2"},
+		Mounts:   []*testMount{{Destination: "/vagrant", Options: []string{"rw"}}, {Destination: "/data"}},
+		Internal: "drop",
+		Nested:   &testConfig{Name: "changed"},
+	}
+
+	result, err := Structs(base, overlay)
+	require.NoError(err)
+
+	require.Equal("base", result.Name)
+	require.Equal(int32(1024), *result.Memory)
+	require.Equal([]string{"a", "b", "c"}, result.Tags)
+	require.Equal(map[string]string{"A": "1", "B": "3"}, result.Env)
+	require.Equal(map[string]string{"y": "2"}, result.Labels)
+	require.Equal("keep", result.Internal)
+	require.Equal("changed", result.Nested.Name)
+	require.Equal(int32(1), *result.Nested.Memory)
+
+	require.Len(result.Mounts, 2)
+	require.Equal("vagrant", *result.Mounts[0].Owner)
+	require.Equal([]string{"ro", "rw"}, result.Mounts[0].Options)
+	require.Equal("/data", result.Mounts[1].Destination)
+
+	// Inputs are not modified
+	require.Equal(int32(512), *base.Memory)
+	require.Equal("2", base.Env["B"])
+	require.Len(base.Mounts, 1)
+	require.Equal([]string{"ro"}, base.Mounts[0].Options)
+}
+
+func TestStructs_nil(t *testing.T) {
+	require := require.New(t)
+
+	result, err := Structs(nil, &testConfig{Name: "overlay"})
+	require.NoError(err)
+	require.Equal("overlay", result.Name)
+
+	result, err = Structs[testConfig](nil, nil)
+	require.NoError(err)
+	require.Nil(result)
+}
+
+func TestStructs_invalidTag(t *testing.T) {
+	type invalid struct {
+		Name string `merge:"nope"`
+	}
+
+	_, err := Structs(&invalid{}, &invalid{})
+	require.Error(t, err)
+}
+
+func TestData(t *testing.T) {
+	require := require.New(t)
+
+	base := &component.ConfigData{
+		Source: "testConfig",
+		Data: map[string]interface{}{
+			"Name":   "base",
+			"Tags":   []interface{}{"a"},
+			"Labels": map[string]interface{}{"x": "1"},
+			"Env":    map[string]interface{}{"A": "1"},
+			"Mounts": []interface{}{
+				map[interface{}]interface{}{"destination": "/vagrant", "Owner": "vagrant"},
+			},
+		},
+	}
+	overlay := &component.ConfigData{
+		Data: map[string]interface{}{
+			"Name":   "overlay",
+			"Tags":   []interface{}{"a", "b"},
+			"Labels": map[string]interface{}{"y": "2"},
+			"Env":    map[string]interface{}{"B": "2"},
+			"Mounts": []interface{}{
+				map[interface{}]interface{}{"destination": "/vagrant", "Owner": "root"},
+			},
+		},
+	}
+
+	result, err := Data(base, overlay, WithStruct(&testConfig{}))
+	require.NoError(err)
+
+	require.Equal("testConfig", result.Source)
+	require.Equal("overlay", result.Data["Name"])
+	require.Equal([]interface{}{"a", "b"}, result.Data["Tags"])
+	require.Equal(map[string]interface{}{"y": "2"}, result.Data["Labels"])
+	require.Equal(map[string]interface{}{"A": "1", "B": "2"}, result.Data["Env"])
+	require.Equal([]interface{}{
+		map[interface{}]interface{}{"destination": "/vagrant", "Owner": "root"},
+	}, result.Data["Mounts"])
+
+	// Without the struct default rules are used
+	result, err = Data(base, overlay)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"x": "1", "y": "2"}, result.Data["Labels"])
+	require.Len(result.Data["Mounts"], 2)
+}
+
+func TestData_provenance(t *testing.T) {
+	require := require.New(t)
+
+	base := &component.ConfigData{
+		Data: map[string]interface{}{"Name": "base", "Tags": []interface{}{"a"}},
+		Provenance: map[string]*component.Provenance{
+			"Name": {Layer: "box"},
+			"Tags": {Layer: "box"},
+		},
+	}
+	overlay := &component.ConfigData{
+		Data:       map[string]interface{}{"Name": "overlay"},
+		Provenance: map[string]*component.Provenance{"Name": {Layer: "project"}},
+	}
+
+	result, err := Data(base, overlay)
+	require.NoError(err)
+	require.Equal("project", result.Provenance["Name"].Layer)
+	require.Equal("box", result.Provenance["Tags"].Layer)
+}
+
+func TestStructs_zeroValues(t *testing.T) {
+	require := require.New(t)
+
+	type config struct {
+		Enabled  bool
+		Count    int
+		Name     string
+		Explicit *bool
+	}
+
+	f := false
+	tr := true
+	result, err := Structs(
+		&config{Enabled: true, Count: 2, Name: "base", Explicit: &tr},
+		&config{Explicit: &f},
+	)
+	require.NoError(err)
+
+	// Zero scalars are treated as unset
+	require.True(result.Enabled)
+	require.Equal(2, result.Count)
+	require.Equal("base", result.Name)
+
+	// Pointers can override with a zero value
+	require.False(*result.Explicit)
+
+	// Within configuration data zero values are applied
+	data, err := Data(
+		&component.ConfigData{Data: map[string]interface{}{"Enabled": true, "Count": 2}},
+		&component.ConfigData{Data: map[string]interface{}{"Enabled": false, "Count": 0}},
+	)
+	require.NoError(err)
+	require.Equal(false, data.Data["Enabled"])
+	require.Equal(0, data.Data["Count"])
+}
+
+func TestDefinedVMs(t *testing.T) {
+	require := require.New(t)
+
+	vagrantfile := &component.ConfigData{
+		Source: "Vagrantfile",
+		Data: map[string]interface{}{
+			"vm": map[string]interface{}{
+				"box":    "hashicorp/bionic64",
+				"Tags":   []interface{}{"global"},
+				"Labels": map[string]interface{}{"x": "1"},
+			},
+			"define_vm": []interface{}{
+				map[string]interface{}{"name": "web", "Tags": []interface{}{"web"}},
+				map[string]interface{}{"name": "db", "box": "hashicorp/focal64"},
+				map[string]interface{}{"name": "web", "Labels": map[string]interface{}{"y": "2"}},
+			},
+		},
+	}
+
+	machines, err := DefinedVMs(vagrantfile, WithStruct(&testConfig{}))
+	require.NoError(err)
+	require.Len(machines, 2)
+
+	web := machines[0]
+	require.Equal("web", web.Name)
+	require.Equal("Vagrantfile", web.Data.Source)
+	require.Equal("hashicorp/bionic64", web.Data.Data["box"])
+	require.Equal([]interface{}{"global", "web"}, web.Data.Data["Tags"])
+	require.Equal(map[string]interface{}{"y": "2"}, web.Data.Data["Labels"])
+
+	db := machines[1]
+	require.Equal("db", db.Name)
+	require.Equal("hashicorp/focal64", db.Data.Data["box"])
+	require.Equal([]interface{}{"global"}, db.Data.Data["Tags"])
+
+	// The global vm is not modified
+	require.Equal("hashicorp/bionic64", vagrantfile.Data["vm"].(map[string]interface{})["box"])
+}
+
+func TestDefinedVMs_default(t *testing.T) {
+	require := require.New(t)
+
+	machines, err := DefinedVMs(&component.ConfigData{
+		Data: map[string]interface{}{
+			"vm": map[string]interface{}{"box": "hashicorp/bionic64"},
+		},
+	})
+	require.NoError(err)
+	require.Len(machines, 1)
+	require.Equal("default", machines[0].Name)
+	require.Equal("hashicorp/bionic64", machines[0].Data.Data["box"])
+
+	_, err = DefinedVMs(&component.ConfigData{
+		Data: map[string]interface{}{
+			"define_vm": []interface{}{map[string]interface{}{"box": "missing-name"}},
+		},
+	})
+	require.Error(err)
+}
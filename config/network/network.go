@@ -15,8 +15,8 @@ type ForwardedPort struct {
 	Protocol    string `hcl:"protocol,optional"`
 	Type        string `hcl:"type,optional"`
 
-	Body   hcl.Body `hcl:",body"`
-	Remain hcl.Body `hcl:",remain"`
+	Body   hcl.Body `hcl:",body" json:"-"`
+	Remain hcl.Body `hcl:",remain" json:"-"`
 }
 
 type Network struct {
@@ -24,6 +24,6 @@ type Network struct {
 	IP         string `hcl:"ip,optional"`
 	Netmask    int32  `hcl:"netmask,optional"`
 
-	Body   hcl.Body `hcl:",body"`
-	Remain hcl.Body `hcl:",remain"`
+	Body   hcl.Body `hcl:",body" json:"-"`
+	Remain hcl.Body `hcl:",remain" json:"-"`
 }
@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// LoadOption modifies how a Vagrantfile is loaded
+type LoadOption func(*loadConfig)
+
+type loadConfig struct {
+	strict    bool
+	diags     *Diagnostics
+	funcs     map[string]function.Function
+	variables map[string]cty.Value
+	layer     string
+}
+
+// WithStrict will cause loading to fail when any errors are
+// encountered while decoding the Vagrantfile. By default,
+// decoding errors (like unknown keys) are ignored.
+func WithStrict() LoadOption {
+	return func(c *loadConfig) {
+		c.strict = true
+	}
+}
+
+// WithDiagnostics provides a value that will be populated with
+// all diagnostics generated while loading the Vagrantfile. This
+// allows problems to be reported when loading is lenient.
+func WithDiagnostics(d *Diagnostics) LoadOption {
+	return func(c *loadConfig) {
+		c.diags = d
+	}
+}
+
+// WithLayer sets the name of the layer recorded as the origin of
+// the values within the Vagrantfile. The default is LayerProject.
+func WithLayer(name string) LoadOption {
+	return func(c *loadConfig) {
+		c.layer = name
+	}
+}
+
+// WithFunctions provides additional functions which will be
+// available within an HCL Vagrantfile, like the functions served
+// by plugins (see component.FunctionProvider).
+func WithFunctions(funcs map[string]function.Function) LoadOption {
+	return func(c *loadConfig) {
+		if c.funcs == nil {
+			c.funcs = map[string]function.Function{}
+		}
+		for name, fn := range funcs {
+			c.funcs[name] = fn
+		}
+	}
+}
+
+// WithVariables provides values for variables defined by var
+// blocks within an HCL Vagrantfile. These values are used in
+// place of the defaults defined by the var blocks.
+func WithVariables(vars map[string]cty.Value) LoadOption {
+	return func(c *loadConfig) {
+		if c.variables == nil {
+			c.variables = map[string]cty.Value{}
+		}
+		for name, val := range vars {
+			c.variables[name] = val
+		}
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package plugins resolves the plugins required by a Vagrantfile
+// against the plugins which are currently installed.
+package plugins
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-version"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/config"
+	"github.com/hashicorp/vagrant-plugin-sdk/core"
+	"github.com/hashicorp/vagrant-plugin-sdk/helper/path"
+	"github.com/hashicorp/vagrant-plugin-sdk/helper/paths"
+	"github.com/hashicorp/vagrant-plugin-sdk/localizer"
+)
+
+// Problem describes a required plugin which is not satisfied
+// by the installed plugins
+type Problem struct {
+	Plugin config.Plugin // Requirement which is not satisfied
+	// Installed version of the plugin. This will be empty if the
+	// plugin is not installed.
+	Installed string
+	Missing   bool  // Plugin is not installed
+	Err       error // Localized description of the problem
+}
+
+// ResolveError is returned when required plugins are missing
+// or incompatible
+type ResolveError struct {
+	Problems []*Problem
+}
+
+func (e *ResolveError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		lines[i] = "  - " + p.Err.Error()
+	}
+
+	return localizer.LocalizeMsg("plugins_unresolved", map[string]string{
+		"Problems": strings.Join(lines, "\n"),
+	})
+}
+
+// Resolver checks plugin requirements against the plugins
+// known to the plugin manager
+type Resolver struct {
+	manager    core.PluginManager
+	installDir path.Path
+	sourceDir  path.Path
+	logger     hclog.Logger
+}
+
+// Option configures a Resolver
+type Option func(*Resolver)
+
+// WithInstallDir sets the directory plugins are installed into. The
+// default is the plugins directory within the Vagrant configuration
+// directory.
+func WithInstallDir(dir path.Path) Option {
+	return func(r *Resolver) {
+		r.installDir = dir
+	}
+}
+
+// WithSourceDir sets the directory relative plugin sources are
+// resolved from, generally the directory of the Vagrantfile. The
+// default is the current working directory.
+func WithSourceDir(dir path.Path) Option {
+	return func(r *Resolver) {
+		r.sourceDir = dir
+	}
+}
+
+// WithLogger sets the logger used by the resolver
+func WithLogger(l hclog.Logger) Option {
+	return func(r *Resolver) {
+		r.logger = l
+	}
+}
+
+// NewResolver creates a new resolver using the plugin manager
+// to determine installed plugins
+func NewResolver(m core.PluginManager, opts ...Option) *Resolver {
+	r := &Resolver{manager: m}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.logger == nil {
+		r.logger = hclog.L()
+	}
+
+	return r
+}
+
+// Check compares the requirements with the installed plugins and
+// returns the requirements which are not satisfied. An error is
+// only returned if the installed plugins could not be listed.
+func (r *Resolver) Check(reqs []config.Plugin) ([]*Problem, error) {
+	list, err := r.manager.ListPlugins()
+	if err != nil {
+		return nil, err
+	}
+
+	// Plugins are listed per component, so collect the
+	// versions by plugin name
+	installed := map[string]string{}
+	for _, p := range list {
+		if v := installed[p.Name]; v == "" {
+			installed[p.Name] = p.Version
+		}
+	}
+
+	var problems []*Problem
+	for _, req := range reqs {
+		if p := r.check(req, installed); p != nil {
+			r.logger.Debug("plugin requirement not satisfied",
+				"plugin", req.Name,
+				"error", p.Err,
+			)
+			problems = append(problems, p)
+		}
+	}
+
+	return problems, nil
+}
+
+// Resolve checks the requirements and returns a ResolveError
+// if any are not satisfied
+func (r *Resolver) Resolve(reqs []config.Plugin) error {
+	problems, err := r.Check(reqs)
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return &ResolveError{Problems: problems}
+	}
+
+	return nil
+}
+
+// Ensure checks the requirements and installs any missing plugins
+// which are available from a local directory source. A ResolveError
+// is returned for any requirements which remain unsatisfied. Newly
+// installed plugins are not available until the plugins are reloaded.
+func (r *Resolver) Ensure(reqs []config.Plugin) error {
+	problems, err := r.Check(reqs)
+	if err != nil {
+		return err
+	}
+
+	var remaining []*Problem
+	for _, p := range problems {
+		if !p.Missing {
+			remaining = append(remaining, p)
+			continue
+		}
+
+		dst, err := r.Install(p.Plugin)
+		if err != nil {
+			p.Err = err
+			remaining = append(remaining, p)
+			continue
+		}
+		r.logger.Info("installed plugin", "plugin", p.Plugin.Name, "path", dst)
+	}
+	if len(remaining) > 0 {
+		return &ResolveError{Problems: remaining}
+	}
+
+	return nil
+}
+
+// Install copies the plugin binary from the first local directory
+// source which contains it into the install directory. The path of
+// the installed binary is returned.
+func (r *Resolver) Install(req config.Plugin) (path.Path, error) {
+	bin, err := BinaryName(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, src := range req.Sources {
+		dir := r.localSource(src)
+		if dir == nil {
+			continue
+		}
+		p := dir.Join(bin)
+		if info, err := os.Stat(p.String()); err != nil || info.IsDir() {
+			continue
+		}
+
+		return r.install(p, bin)
+	}
+
+	return nil, localizer.LocalizeErr("plugin_install_no_source", map[string]string{
+		"Name":    req.Name,
+		"Binary":  bin,
+		"Sources": strings.Join(req.Sources, ", "),
+	})
+}
+
+// Required returns the plugins required within the vagrant
+// block of the Vagrantfile
+func Required(v *config.Vagrantfile) []config.Plugin {
+	if v == nil || v.Vagrant == nil {
+		return nil
+	}
+
+	return v.Vagrant.Plugins
+}
+
+// BinaryName returns the name of the binary for the plugin. The entry
+// point is used when set, otherwise the plugin name is used. Since the
+// name is used as a file name within the plugins directory, an error
+// is returned if it is not a plain file name.
+func BinaryName(req config.Plugin) (string, error) {
+	name := req.Name
+	if req.EntryPoint != nil && *req.EntryPoint != "" {
+		name = *req.EntryPoint
+	}
+	if name == "." || name == ".." || strings.ContainsAny(name, `/\`) ||
+		filepath.Base(name) != name {
+		return "", fmt.Errorf("invalid binary name %q for plugin %q", name, req.Name)
+	}
+	if runtime.GOOS == "windows" && !strings.HasSuffix(name, ".exe") {
+		name += ".exe"
+	}
+
+	return name, nil
+}
+
+func (r *Resolver) check(req config.Plugin, installed map[string]string) *Problem {
+	installedVersion, ok := installed[req.Name]
+	if !ok {
+		return &Problem{
+			Plugin:  req,
+			Missing: true,
+			Err: localizer.LocalizeErr("plugin_missing", map[string]string{
+				"Name": req.Name,
+			}),
+		}
+	}
+	if req.Version == nil || strings.TrimSpace(*req.Version) == "" {
+		return nil
+	}
+
+	constraints, err := version.NewConstraint(*req.Version)
+	if err != nil {
+		return &Problem{
+			Plugin:    req,
+			Installed: installedVersion,
+			Err: localizer.LocalizeErr("plugin_invalid_version_constraint", map[string]string{
+				"Name":       req.Name,
+				"Constraint": *req.Version,
+				"Error":      err.Error(),
+			}),
+		}
+	}
+
+	// An unknown version can not satisfy a version requirement
+	v, err := version.NewVersion(installedVersion)
+	if err == nil && constraints.Check(v) {
+		return nil
+	}
+	if installedVersion == "" {
+		installedVersion = "unknown"
+	}
+
+	return &Problem{
+		Plugin:    req,
+		Installed: installedVersion,
+		Err: localizer.LocalizeErr("plugin_version_incompatible", map[string]string{
+			"Name":       req.Name,
+			"Version":    installedVersion,
+			"Constraint": *req.Version,
+		}),
+	}
+}
+
+// localSource returns the directory for the source if it is
+// a local directory
+func (r *Resolver) localSource(src string) path.Path {
+	if strings.HasPrefix(src, "file://") {
+		src = strings.TrimPrefix(src, "file://")
+	} else if strings.Contains(src, "://") {
+		return nil
+	}
+
+	p := path.NewPath(src)
+	if !p.IsAbs() && r.sourceDir != nil {
+		p = r.sourceDir.Join(src)
+	}
+	if info, err := os.Stat(p.String()); err != nil || !info.IsDir() {
+		return nil
+	}
+
+	return p
+}
+
+func (r *Resolver) install(src path.Path, bin string) (path.Path, error) {
+	dir := r.installDir
+	if dir == nil {
+		cfg, err := paths.VagrantConfig()
+		if err != nil {
+			return nil, err
+		}
+		dir = cfg.Join("plugins")
+	}
+	if err := os.MkdirAll(dir.String(), 0755); err != nil {
+		return nil, err
+	}
+
+	in, err := os.Open(src.String())
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	dst := dir.Join(bin)
+	out, err := os.OpenFile(dst.String(), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		out.Close()
+		return nil, fmt.Errorf("failed to install plugin %s: %w", bin, err)
+	}
+	if err = out.Close(); err != nil {
+		return nil, err
+	}
+
+	return dst, nil
+}
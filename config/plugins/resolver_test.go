@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/config"
+	"github.com/hashicorp/vagrant-plugin-sdk/core"
+	"github.com/hashicorp/vagrant-plugin-sdk/core/mocks"
+	"github.com/hashicorp/vagrant-plugin-sdk/helper/path"
+)
+
+func strPtr(s string) *string { return &s }
+
+func testManager(t *testing.T) *mocks.PluginManager {
+	m := mocks.NewPluginManager(t)
+	m.On("ListPlugins").Return([]*core.NamedPlugin{
+		{Name: "docker", Type: "provider", Version: "1.4.0"},
+		{Name: "docker", Type: "host", Version: "1.4.0"},
+		{Name: "local", Type: "command"},
+	}, nil)
+
+	return m
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	require := require.New(t)
+
+	v, err := config.LoadVagrantfile([]byte(`
+vagrant {
+  plugins "docker" {
+    version = "~> 1.2"
+  }
+
+  plugins "local" {}
+}
+`), "Vagrantfile.hcl", config.HCL)
+	require.NoError(err)
+
+	r := NewResolver(testManager(t))
+	require.NoError(r.Resolve(Required(v)))
+
+	err = r.Resolve([]config.Plugin{
+		{Name: "docker", Version: strPtr(">= 2.0")},
+		{Name: "local", Version: strPtr("1.0")},
+		{Name: "missing"},
+		{Name: "docker", Version: strPtr("=> 1")},
+	})
+	require.Error(err)
+
+	rerr, ok := err.(*ResolveError)
+	require.True(ok)
+	require.Len(rerr.Problems, 4)
+	require.Equal("1.4.0", rerr.Problems[0].Installed)
+	require.Equal("unknown", rerr.Problems[1].Installed)
+	require.True(rerr.Problems[2].Missing)
+	require.Contains(rerr.Problems[3].Err.Error(), "=> 1")
+
+	require.Contains(err.Error(), "The plugin 'missing' is required")
+	require.Contains(err.Error(), "requires '>= 2.0'")
+}
+
+func TestResolver_Ensure(t *testing.T) {
+	require := require.New(t)
+
+	src := t.TempDir()
+	dst := t.TempDir()
+	bin, err := BinaryName(config.Plugin{Name: "example", EntryPoint: strPtr("vagrant-example")})
+	require.NoError(err)
+	require.NoError(os.WriteFile(filepath.Join(src, bin), []byte("binary"), 0755))
+
+	r := NewResolver(testManager(t),
+		WithInstallDir(path.NewPath(dst)),
+		WithSourceDir(path.NewPath(filepath.Dir(src))),
+	)
+	err = r.Ensure([]config.Plugin{
+		{
+			Name:       "example",
+			EntryPoint: strPtr("vagrant-example"),
+			Sources:    []string{"https://example.com/plugins", filepath.Base(src)},
+		},
+	})
+	require.NoError(err)
+
+	content, err := os.ReadFile(filepath.Join(dst, bin))
+	require.NoError(err)
+	require.Equal("binary", string(content))
+
+	err = r.Ensure([]config.Plugin{
+		{Name: "other", Sources: []string{"file://" + src}},
+	})
+	require.Error(err)
+	require.Contains(err.Error(), "could not be installed")
+}
+
+func TestResolver_checkVersion(t *testing.T) {
+	cases := []struct {
+		constraint string
+		version    string
+		ok         bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3", "v1.2.3", true},
+		{"= 1.2", "1.2.0", true},
+		{"!= 1.2", "1.2.1", true},
+		{"> 1.2", "1.2.0", false},
+		{">= 1.2, < 2", "1.9.9", true},
+		{">= 1.2, < 2", "2.0.0", false},
+		{"~> 1.2", "1.9.0", true},
+		{"~> 1.2", "2.0.0", false},
+		{"~> 1.2.3", "1.2.9", true},
+		{"~> 1.2.3", "1.3.0", false},
+		{"~> 1.2", "", false},
+	}
+
+	r := NewResolver(nil)
+	for _, tc := range cases {
+		t.Run(tc.constraint+" "+tc.version, func(t *testing.T) {
+			p := r.check(
+				config.Plugin{Name: "example", Version: strPtr(tc.constraint)},
+				map[string]string{"example": tc.version},
+			)
+			require.Equal(t, tc.ok, p == nil)
+		})
+	}
+}
+
+func TestBinaryName(t *testing.T) {
+	require := require.New(t)
+
+	name, err := BinaryName(config.Plugin{Name: "example"})
+	require.NoError(err)
+	require.Contains(name, "example")
+
+	for _, entry := range []string{"../../bin/x", "bin/x", `..\x`, ".."} {
+		_, err := BinaryName(config.Plugin{Name: "example", EntryPoint: strPtr(entry)})
+		require.Error(err, entry)
+	}
+}
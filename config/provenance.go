@@ -0,0 +1,244 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+)
+
+// Provenance returns where each value within the configuration of
+// the machine was set. Values are keyed by their path within the
+// data returned from ConfigData, with labeled blocks identified by
+// their labels, like "vm.provider[virtualbox]".
+func (v *Vagrantfile) Provenance(machine string) (map[string]*component.Provenance, error) {
+	vm, err := v.machine(machine)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]*component.Provenance{}
+	for k, o := range v.Origins {
+		result[k] = o.Provenance()
+	}
+	for k, o := range vm.Origins {
+		result["vm."+k] = o.Provenance()
+	}
+
+	return result, nil
+}
+
+// ConfigData returns the configuration of the machine, including the
+// vagrant and ssh blocks, along with the provenance of its values.
+// Data is keyed using the names used within the Vagrantfile.
+func (v *Vagrantfile) ConfigData(machine string) (*component.ConfigData, error) {
+	prov, err := v.Provenance(machine)
+	if err != nil {
+		return nil, err
+	}
+	vm, _ := v.machine(machine)
+
+	data := map[string]interface{}{}
+	for name, block := range map[string]interface{}{
+		"vagrant": v.Vagrant,
+		"ssh":     v.SSH,
+		"vm":      vm,
+	} {
+		if m := blockData(reflect.ValueOf(block)); len(m) > 0 {
+			data[name] = m
+		}
+	}
+
+	return &component.ConfigData{
+		Source:     "Vagrantfile",
+		Data:       data,
+		Provenance: prov,
+	}, nil
+}
+
+// RenderBlame outputs a table of the configuration values with the
+// layer and location where each was set
+func RenderBlame(ui terminal.UI, data *component.ConfigData) {
+	keys := make([]string, 0, len(data.Provenance))
+	for k := range data.Provenance {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	tbl := terminal.NewTable("Path", "Value", "Layer", "Location")
+	for _, k := range keys {
+		p := data.Provenance[k]
+		location := p.File
+		if p.Range.Start.Line > 0 {
+			location = fmt.Sprintf("%s:%d", p.File, p.Range.Start.Line)
+		}
+
+		value := ""
+		if val, ok := lookupValue(data.Data, k); ok {
+			value = fmt.Sprintf("%v", val)
+		}
+
+		tbl.Rich([]string{k, value, p.Layer, location}, nil)
+	}
+
+	ui.Table(tbl)
+}
+
+func (v *Vagrantfile) machine(name string) (*VM, error) {
+	for _, vm := range v.ListVMs {
+		if vm.Name == name {
+			return vm, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no machine named %q is defined", name)
+}
+
+// blockData converts the configuration block into a map keyed
+// by the hcl names of the fields which are set
+func blockData(rv reflect.Value) map[string]interface{} {
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	result := map[string]interface{}{}
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, kind, _ := strings.Cut(t.Field(i).Tag.Get("hcl"), ",")
+		fv := rv.Field(i)
+		if name == "" || fv.IsZero() {
+			continue
+		}
+
+		if kind != "block" {
+			for fv.Kind() == reflect.Ptr {
+				fv = fv.Elem()
+			}
+			result[name] = fv.Interface()
+			continue
+		}
+
+		if fv.Kind() == reflect.Slice {
+			list := make([]interface{}, 0, fv.Len())
+			for j := 0; j < fv.Len(); j++ {
+				list = append(list, blockData(fv.Index(j)))
+			}
+			result[name] = list
+			continue
+		}
+		result[name] = blockData(fv)
+	}
+
+	return result
+}
+
+// configDataTypes are the types of the blocks within the
+// data returned from ConfigData
+var configDataTypes = map[string]reflect.Type{
+	"vagrant": reflect.TypeOf(Vagrant{}),
+	"ssh":     reflect.TypeOf(SSH{}),
+	"vm":      reflect.TypeOf(VM{}),
+}
+
+// lookupValue returns the value at the path within the data
+// returned from ConfigData. Labeled blocks within the path are
+// identified by their labels, like "vm.provider[virtualbox]".
+func lookupValue(data map[string]interface{}, path string) (interface{}, bool) {
+	var cur interface{} = data
+	var typ reflect.Type
+	for i, seg := range strings.Split(path, ".") {
+		name, labels, labeled := strings.Cut(strings.TrimSuffix(seg, "]"), "[")
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = m[name]; !ok {
+			return nil, false
+		}
+
+		if i == 0 {
+			typ = configDataTypes[name]
+		} else {
+			typ = hclFieldType(typ, name)
+		}
+		if !labeled {
+			continue
+		}
+
+		list, ok := cur.([]interface{})
+		if !ok || typ == nil || typ.Kind() != reflect.Slice {
+			return nil, false
+		}
+		typ = typ.Elem()
+		fields := labelFields(typ)
+
+		cur = nil
+		for _, item := range list {
+			im, _ := item.(map[string]interface{})
+			values := make([]string, len(fields))
+			for j, f := range fields {
+				values[j] = fmt.Sprint(im[f])
+			}
+			if len(fields) > 0 && strings.Join(values, ",") == labels {
+				cur = item
+				break
+			}
+		}
+		if cur == nil {
+			return nil, false
+		}
+	}
+
+	return cur, true
+}
+
+// hclFieldType returns the type of the field of the struct
+// with the hcl name
+func hclFieldType(t reflect.Type, name string) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if n, _, _ := strings.Cut(t.Field(i).Tag.Get("hcl"), ","); n == name {
+			return t.Field(i).Type
+		}
+	}
+
+	return nil
+}
+
+// labelFields returns the hcl names of the label fields
+// of the block struct
+func labelFields(t reflect.Type) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		if n, kind, _ := strings.Cut(t.Field(i).Tag.Get("hcl"), ","); kind == "label" {
+			names = append(names, n)
+		}
+	}
+
+	return names
+}
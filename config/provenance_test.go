@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+)
+
+func TestVagrantfile_ConfigData(t *testing.T) {
+	require := require.New(t)
+
+	v, err := LoadVagrantfile([]byte(`
+vagrant {
+  host = "linux"
+}
+
+vm {
+  box = "hashicorp/bionic64"
+}
+
+define_vm "web" {
+  hostname = "web"
+
+  provider "virtualbox" {}
+}
+`), "Vagrantfile.hcl", HCL, WithStrict())
+	require.NoError(err)
+
+	data, err := v.ConfigData("web")
+	require.NoError(err)
+
+	vm := data.Data["vm"].(map[string]interface{})
+	require.Equal("hashicorp/bionic64", vm["box"])
+	require.Equal("web", vm["hostname"])
+	require.Equal("linux", data.Data["vagrant"].(map[string]interface{})["host"])
+
+	box := data.Provenance["vm.box"]
+	require.Equal(LayerProject, box.Layer)
+	require.Equal("Vagrantfile.hcl", box.File)
+	require.Equal(7, box.Range.Start.Line)
+
+	hostname := data.Provenance["vm.hostname"]
+	require.Equal(LayerDefineVM, hostname.Layer)
+	require.Equal(11, hostname.Range.Start.Line)
+
+	require.Equal(13, data.Provenance["vm.provider[virtualbox]"].Range.Start.Line)
+	require.Equal(3, data.Provenance["vagrant.host"].Range.Start.Line)
+
+	_, err = v.ConfigData("db")
+	require.Error(err)
+}
+
+func TestRenderBlame(t *testing.T) {
+	require := require.New(t)
+
+	v, err := LoadVagrantfile([]byte(`
+vm {
+  box      = "hashicorp/bionic64"
+  hostname = "example"
+
+  provider "virtualbox" {
+    memory = 2048
+  }
+}
+`), "Vagrantfile.hcl", HCL, WithStrict(), WithLayer(LayerHome))
+	require.NoError(err)
+
+	data, err := v.ConfigData("default")
+	require.NoError(err)
+
+	var buf bytes.Buffer
+	orig := color.Output
+	color.Output = &buf
+	t.Cleanup(func() { color.Output = orig })
+
+	RenderBlame(terminal.NonInteractiveUI(context.Background()), data)
+
+	out := buf.String()
+	require.Contains(out, "vm.box")
+	require.Contains(out, "hashicorp/bionic64")
+	require.Contains(out, "Vagrantfile.hcl:3")
+	require.Contains(out, "home")
+	require.Regexp(`vm\.provider\[virtualbox\]\s+\|\s+map\[name:virtualbox\]`, out)
+
+	val, ok := lookupValue(data.Data, "vm.provider[virtualbox].name")
+	require.True(ok)
+	require.Equal("virtualbox", val)
+	_, ok = lookupValue(data.Data, "vm.provider[libvirt]")
+	require.False(ok)
+}
@@ -15,7 +15,7 @@ type Shell struct {
 	Args                          []string          `hcl:"args,optional"`
 	Binary                        bool              `hcl:"binary,optional"`
 	Env                           map[string]string `hcl:"env,optional"`
-	KeepColor                     bool              `hcl:"keep_color.optional"`
+	KeepColor                     bool              `hcl:"keep_color,optional"`
 	MD5                           string            `hcl:"md5,optional"`
 	Name                          string            `hcl:"name,optional"`
 	PowershellArgs                []string          `hcl:"powershell_args,optional"`
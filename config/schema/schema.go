@@ -0,0 +1,351 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package schema generates JSON Schema documents from configuration
+// structures using their hcl tags. The generated schema describes the
+// JSON representation of the configuration, which is the format used
+// by JSON Vagrantfiles and the JSON produced from Ruby Vagrantfiles.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/mitchellh/protostructure"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/docs"
+)
+
+// Draft is the JSON Schema draft used for generated documents
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON Schema document
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	ID          string `json:"$id,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+}
+
+// Option modifies how a schema is generated
+type Option func(*generator)
+
+type generator struct {
+	id          string
+	title       string
+	description string
+	docs        *docs.Documentation
+	args        []argmapper.Arg
+}
+
+// WithID sets the $id of the generated schema
+func WithID(id string) Option {
+	return func(g *generator) { g.id = id }
+}
+
+// WithTitle sets the title of the generated schema
+func WithTitle(title string) Option {
+	return func(g *generator) { g.title = title }
+}
+
+// WithDescription sets the description of the generated schema
+func WithDescription(d string) Option {
+	return func(g *generator) { g.description = d }
+}
+
+// WithDocs adds the synopsis, summary and default value of
+// documented fields to the top level properties of the schema
+func WithDocs(d *docs.Documentation) Option {
+	return func(g *generator) { g.docs = d }
+}
+
+// WithArgs provides arguments used when calling a configuration
+// component's struct function
+func WithArgs(args ...argmapper.Arg) Option {
+	return func(g *generator) { g.args = append(g.args, args...) }
+}
+
+// Generate a schema from a configuration structure. The value
+// must be a struct or pointer to a struct.
+func Generate(v interface{}, opts ...Option) (*Schema, error) {
+	g := &generator{}
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid config type, must be struct")
+	}
+
+	s, err := g.body(t)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Schema = Draft
+	s.ID = g.id
+	s.Title = g.title
+	s.Description = g.description
+
+	if g.docs != nil {
+		g.document(s)
+	}
+
+	return s, nil
+}
+
+// FromConfig generates a schema from the configuration structure
+// of a config component
+func FromConfig(c component.Config, opts ...Option) (*Schema, error) {
+	g := &generator{}
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	v, err := g.structValue(c.StructFunc())
+	if err != nil {
+		return nil, err
+	}
+
+	return Generate(v, opts...)
+}
+
+// structValue resolves the value returned from a StructFunc
+func (g *generator) structValue(fn interface{}) (interface{}, error) {
+	f, ok := fn.(*argmapper.Func)
+	if !ok {
+		var err error
+		if f, err = argmapper.NewFunc(fn); err != nil {
+			return nil, fmt.Errorf("unsupported struct function type %T: %w", fn, err)
+		}
+	}
+
+	result := f.Call(g.args...)
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
+	if result.Len() == 0 {
+		return nil, fmt.Errorf("struct function returned no value")
+	}
+	raw := result.Out(0)
+
+	// Structures from remote plugins are encoded
+	if s, ok := raw.(*protostructure.Struct); ok {
+		return protostructure.New(s)
+	}
+
+	return raw, nil
+}
+
+var bodyType = reflect.TypeOf((*hcl.Body)(nil)).Elem()
+
+// body generates the schema of an HCL body from a struct
+func (g *generator) body(t reflect.Type) (*Schema, error) {
+	s := &Schema{
+		Type:                 "object",
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: false,
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("hcl")
+		if !ok {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		name, kind := parts[0], "attr"
+		if len(parts) > 1 {
+			kind = parts[1]
+		}
+
+		switch kind {
+		case "attr", "optional":
+			prop, err := g.value(f.Type)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			s.Properties[name] = prop
+			if kind == "attr" {
+				s.Required = append(s.Required, name)
+			}
+		case "block":
+			prop, required, err := g.block(f.Type)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			s.Properties[name] = prop
+			if required {
+				s.Required = append(s.Required, name)
+			}
+		case "remain":
+			// Unknown content is allowed within the body
+			s.AdditionalProperties = true
+		case "label", "body":
+			// Labels are the keys of the parent and the
+			// body is the raw content
+		default:
+			return nil, fmt.Errorf("field %s: invalid hcl tag kind %q", f.Name, kind)
+		}
+	}
+
+	sort.Strings(s.Required)
+
+	return s, nil
+}
+
+// block generates the schema of a nested block. Blocks with
+// labels are represented by nested objects keyed by label
+// value.
+func (g *generator) block(t reflect.Type) (*Schema, bool, error) {
+	required, multiple := true, false
+	switch t.Kind() {
+	case reflect.Ptr:
+		required = false
+		t = t.Elem()
+	case reflect.Slice:
+		required, multiple = false, true
+		t = t.Elem()
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, false, fmt.Errorf("block must be a struct, got %s", t)
+	}
+
+	s, err := g.body(t)
+	if err != nil {
+		return nil, false, err
+	}
+
+	labels := 0
+	for i := 0; i < t.NumField(); i++ {
+		if tag := t.Field(i).Tag.Get("hcl"); strings.HasSuffix(tag, ",label") {
+			labels++
+		}
+	}
+
+	// Unlabeled blocks which can be defined multiple
+	// times can be provided as an object or list
+	if labels == 0 && multiple {
+		return &Schema{
+			AnyOf: []*Schema{s, {Type: "array", Items: s}},
+		}, false, nil
+	}
+
+	for i := 0; i < labels; i++ {
+		s = &Schema{
+			Type:                 "object",
+			AdditionalProperties: s,
+		}
+	}
+
+	return s, required, nil
+}
+
+// value generates the schema for an attribute value
+func (g *generator) value(t reflect.Type) (*Schema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := g.value(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("map keys must be strings, got %s", t.Key())
+		}
+		items, err := g.value(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: items}, nil
+	case reflect.Struct:
+		// Structs used as attribute values (like cty.Value)
+		// can be anything
+		return &Schema{}, nil
+	case reflect.Interface:
+		if t.Implements(bodyType) {
+			return nil, fmt.Errorf("body must use a body or remain tag")
+		}
+		return &Schema{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// document adds field documentation to the top level properties
+func (g *generator) document(s *Schema) {
+	for _, f := range g.docs.Fields() {
+		prop, ok := s.Properties[f.Field]
+		if !ok {
+			continue
+		}
+
+		var desc []string
+		if f.Synopsis != "" {
+			desc = append(desc, f.Synopsis)
+		}
+		if f.Summary != "" {
+			desc = append(desc, f.Summary)
+		}
+		if f.EnvVar != "" {
+			desc = append(desc, fmt.Sprintf("Can be set with the %s environment variable.", f.EnvVar))
+		}
+		prop.Description = strings.Join(desc, "\n\n")
+
+		if f.Default != "" {
+			prop.Default = defaultValue(prop, f.Default)
+		}
+	}
+}
+
+// Defaults are documented as strings, so convert them to
+// the type of the property when possible
+func defaultValue(s *Schema, d string) interface{} {
+	if s.Type == "string" || s.Type == "" {
+		return d
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(d), &v); err != nil {
+		return d
+	}
+
+	return v
+}
@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/docs"
+)
+
+type testConfig struct {
+	Name    string            `hcl:"name"`
+	Memory  *int32            `hcl:"memory,optional"`
+	Ratio   float64           `hcl:"ratio,optional"`
+	Tags    []string          `hcl:"tags,optional"`
+	Options map[string]string `hcl:"options,optional"`
+
+	Limits  testLimits   `hcl:"limits,block"`
+	Hooks   []*testHook  `hcl:"hook,block"`
+	Mounts  []*testMount `hcl:"mount,block"`
+	Network *testNetwork `hcl:"network,block"`
+	Ignored string
+	Body    hcl.Body `hcl:",body"`
+}
+
+type testLimits struct {
+	CPUs int `hcl:"cpus,optional"`
+}
+
+type testHook struct {
+	Run string `hcl:"run"`
+}
+
+type testMount struct {
+	Source      string `hcl:"source,label"`
+	Destination string `hcl:"destination,label"`
+	ReadOnly    bool   `hcl:"read_only,optional"`
+}
+
+type testNetwork struct {
+	Kind   string   `hcl:"kind,label"`
+	Remain hcl.Body `hcl:",remain"`
+}
+
+func TestGenerate(t *testing.T) {
+	require := require.New(t)
+
+	s, err := Generate(&testConfig{}, WithTitle("test"))
+	require.NoError(err)
+
+	require.Equal(Draft, s.Schema)
+	require.Equal("test", s.Title)
+	require.Equal(false, s.AdditionalProperties)
+	require.Equal([]string{"limits", "name"}, s.Required)
+	require.NotContains(s.Properties, "Ignored")
+
+	require.Equal("string", s.Properties["name"].Type)
+	require.Equal("integer", s.Properties["memory"].Type)
+	require.Equal("number", s.Properties["ratio"].Type)
+	require.Equal("array", s.Properties["tags"].Type)
+	require.Equal("string", s.Properties["tags"].Items.Type)
+	require.Equal(&Schema{Type: "string"}, s.Properties["options"].AdditionalProperties)
+
+	// Unlabeled repeatable blocks may be an object or list of objects
+	hooks := s.Properties["hook"]
+	require.Len(hooks.AnyOf, 2)
+	require.Equal("array", hooks.AnyOf[1].Type)
+	require.Equal([]string{"run"}, hooks.AnyOf[0].Required)
+
+	// Each label adds a level of nesting
+	mount := s.Properties["mount"].AdditionalProperties.(*Schema).AdditionalProperties.(*Schema)
+	require.Contains(mount.Properties, "read_only")
+	require.NotContains(mount.Properties, "source")
+
+	network := s.Properties["network"].AdditionalProperties.(*Schema)
+	require.Equal(true, network.AdditionalProperties)
+}
+
+func TestGenerate_invalid(t *testing.T) {
+	_, err := Generate("nope")
+	require.Error(t, err)
+
+	_, err = Generate(&struct {
+		Ch chan int `hcl:"ch"`
+	}{})
+	require.Error(t, err)
+}
+
+func TestGenerate_docs(t *testing.T) {
+	require := require.New(t)
+
+	d, err := docs.New(docs.FromConfig(&testLimits{}))
+	require.NoError(err)
+	require.NoError(d.SetField("cpus", "number of cpus",
+		docs.Summary("defaults to the host cpu count"),
+		docs.Default("2"),
+		docs.EnvVar("TEST_CPUS"),
+	))
+
+	s, err := Generate(&testLimits{}, WithDocs(d))
+	require.NoError(err)
+
+	cpus := s.Properties["cpus"]
+	require.Contains(cpus.Description, "number of cpus")
+	require.Contains(cpus.Description, "defaults to the host cpu count")
+	require.Contains(cpus.Description, "TEST_CPUS")
+	require.Equal(float64(2), cpus.Default)
+}
+
+type testComponent struct {
+	component.Config
+}
+
+func (c *testComponent) StructFunc() interface{} {
+	return func() *testLimits { return &testLimits{} }
+}
+
+func TestFromConfig(t *testing.T) {
+	require := require.New(t)
+
+	s, err := FromConfig(&testComponent{}, WithArgs(argmapper.Typed(context.Background())))
+	require.NoError(err)
+	require.Contains(s.Properties, "cpus")
+
+	_, err = FromConfig(&testErrComponent{}, WithArgs(argmapper.Typed(context.Background())))
+	require.EqualError(err, "unavailable")
+}
+
+type testErrComponent struct {
+	component.Config
+}
+
+func (c *testErrComponent) StructFunc() interface{} {
+	return func(context.Context) (interface{}, error) {
+		return nil, errors.New("unavailable")
+	}
+}
+
+func TestVagrantfile(t *testing.T) {
+	require := require.New(t)
+
+	s, err := Vagrantfile()
+	require.NoError(err)
+
+	for _, k := range []string{"vagrant", "vm", "define_vm", "ssh", "var", "locals"} {
+		require.Contains(s.Properties, k)
+	}
+
+	v := s.Properties["var"].AdditionalProperties.(*Schema)
+	require.Contains(v.Properties, "default")
+	require.Equal("string", v.Properties["description"].Type)
+
+	_, err = json.Marshal(s)
+	require.NoError(err)
+}
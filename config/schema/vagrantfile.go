@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"github.com/hashicorp/vagrant-plugin-sdk/config"
+)
+
+// Vagrantfile generates the schema of a Vagrantfile. The schema
+// includes the var and locals blocks which are evaluated before
+// the Vagrantfile is decoded.
+func Vagrantfile(opts ...Option) (*Schema, error) {
+	opts = append([]Option{WithTitle("Vagrantfile")}, opts...)
+	s, err := Generate(&config.Vagrantfile{}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Properties["var"] = &Schema{
+		Type: "object",
+		AdditionalProperties: &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"default":     {},
+				"description": {Type: "string"},
+			},
+			AdditionalProperties: false,
+		},
+	}
+	s.Properties["locals"] = &Schema{
+		Type:                 "object",
+		AdditionalProperties: true,
+	}
+
+	return s, nil
+}
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+// SensitiveValues returns the values within the Vagrantfile which
+// must not be displayed. This includes the values set within the
+// vagrant block and the environment values of shell provisioners
+// marked as sensitive.
+//
+// Loading a Vagrantfile does not register these values. The caller
+// displaying output is responsible for registering them, usually
+// with terminal.AddSensitive.
+func (v *Vagrantfile) SensitiveValues() []string {
+	var values []string
+	if v.Vagrant != nil {
+		values = append(values, v.Vagrant.Sensitive...)
+	}
+
+	vms := []*VM{v.VM}
+	vms = append(vms, v.DefinedVms...)
+	vms = append(vms, v.ListVMs...)
+	for _, vm := range vms {
+		if vm == nil {
+			continue
+		}
+		for _, p := range vm.Provisioners {
+			if p == nil || p.Shell == nil || !p.Shell.Sensitive {
+				continue
+			}
+			for _, val := range p.Shell.Env {
+				values = append(values, val)
+			}
+		}
+	}
+
+	return values
+}
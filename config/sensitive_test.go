@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+)
+
+func TestVagrantfile_SensitiveValues(t *testing.T) {
+	require := require.New(t)
+	t.Cleanup(terminal.ClearSensitive)
+
+	v, err := LoadVagrantfile([]byte(`
+vagrant {
+  sensitive = ["api-token"]
+}
+
+vm {
+  provisioner "shell" {
+    inline    = "echo $PASSWORD"
+    sensitive = true
+    env = {
+      PASSWORD = "hunter2"
+    }
+  }
+}
+
+define_vm "web" {
+  provisioner "shell" {
+    inline = "echo $USER"
+    env = {
+      USER = "vagrant"
+    }
+  }
+}
+`), "Vagrantfile.hcl", HCL, WithStrict())
+	require.NoError(err)
+
+	values := v.SensitiveValues()
+	require.Contains(values, "api-token")
+	require.Contains(values, "hunter2")
+	require.NotContains(values, "vagrant")
+
+	// Values are only registered by the caller
+	require.Empty(terminal.SensitiveValues())
+	terminal.AddSensitive(values...)
+	require.Equal("token ***** password *****",
+		terminal.Redact("token api-token password hunter2"))
+}
@@ -5,6 +5,11 @@ package config
 
 import (
 	"github.com/hashicorp/hcl/v2"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/config/merge"
+	"github.com/hashicorp/vagrant-plugin-sdk/config/network"
+	"github.com/hashicorp/vagrant-plugin-sdk/config/provider"
+	"github.com/hashicorp/vagrant-plugin-sdk/config/provisioner"
 )
 
 type Vagrantfile struct {
@@ -14,17 +19,32 @@ type Vagrantfile struct {
 	Vagrant       *Vagrant        `hcl:"vagrant,block" json:",omitempty"`
 	VM            *VM             `hcl:"vm,block" json:",omitempty"`
 
-	// These are values which are set after finalizations
+	// These are values which are set after finalizations. The
+	// define_vm blocks are resolved by applying the global vm
+	// block to each, see Finalize.
 
 	DefinedVmKeys []string `json:",omitempty"`
 	ListVMs       []*VM    `json:",omitempty"`
 
+	// Origins records the layer which set the values of the
+	// vagrant and ssh blocks when layers are merged. Machine
+	// values are recorded within the Origins of each VM.
+	Origins map[string]*Origin `json:"-"`
+
 	Body   hcl.Body `hcl:",body" json:"-"`
 	Remain hcl.Body `hcl:",remain" json:"-"`
+
+	// EvalContext is the context used when decoding the Vagrantfile.
+	// Plugins should use it when decoding their own blocks so that
+	// variables and functions are available.
+	EvalContext *hcl.EvalContext `json:"-"`
 }
 
 type SSH struct {
 	ConnectTimeout *int32 `hcl:"connect_timeout,optional" json:",omitempty"`
+
+	Body   hcl.Body `hcl:",body" json:"-"`
+	Remain hcl.Body `hcl:",remain" json:"-"`
 }
 
 type Communicator struct {
@@ -71,37 +91,39 @@ type VM struct {
 	BoxDownloadLocationTrusted *bool             `hcl:"box_download_location_trusted,optional" json:",omitempty"`
 	BoxURL                     *string           `hcl:"box_url,optional" json:",omitempty"`
 	BoxVersion                 *string           `hcl:"box_version,optional" json:",omitempty"`
-	//	CloudInit                  *CloudInit        `hcl:"cloud_init,block" json:",omitempty"`
-	Communicator *string `hcl:"communicator,optional" json:",omitempty"`
-	// Disk                       *Disk             `hcl:"disk,optional" json:",omitempty"`
-	GracefulHaltTimeout  *int32  `hcl:"graceful_halt_timeout,optional" json:",omitempty"`
-	Guest                *string `hcl:"guest,optional" json:",omitempty"`
-	Hostname             *string `hcl:"hostname,optional" json:",omitempty"`
-	IgnoreBoxVagrantfile *bool   `hcl:"ignore_box_vagrantfile,optional" json:",omitempty"`
-	// Networks                   []*Network        `hcl:"network,block" json:",omitempty"`
-	PostUpMessage *string `hcl:"post_up_message,optional" json:",omitempty"`
-	Primary       *bool   `hcl:"primary,optional" json:",omitempty"`
-	// Providers                  []*Provider       `hcl:"provider,block" json:",omitempty"`
-	// Provisioners               []*Provisioner    `hcl:"provisioner,block" json:",omitempty"`
-	// SyncedFolders              []*SyncedFolder   `hcl:"synced_folder,block" json:",omitempty"`
-	// UsablePortRange            *Range            `hcl:"usable_port_range,block" json:",omitempty"`
-
-	//	Provider *Provider `json:",omitempty"`
-
-	// Body   hcl.Body `hcl:",body" json:"-"`
-	// Remain hcl.Body `hcl:",remain" json:"-"`
-}
-
-// Here with looking at DefinedVMs which are nested Vagrantfiles and how the
-// naming will work with named subvms and the default. Pretty sure we are going
-// to want to modify the structure on the Ruby side before we serialize and ship
-// back over the wire
+	CloudInit                  *CloudInit        `hcl:"cloud_init,block" json:",omitempty"`
+	Communicator               *string           `hcl:"communicator,optional" json:",omitempty"`
+	Disks                      []*Disk           `hcl:"disk,block" json:",omitempty" merge:"append,key=name"`
+	GracefulHaltTimeout        *int32            `hcl:"graceful_halt_timeout,optional" json:",omitempty"`
+	Guest                      *string           `hcl:"guest,optional" json:",omitempty"`
+	Hostname                   *string           `hcl:"hostname,optional" json:",omitempty"`
+	IgnoreBoxVagrantfile       *bool             `hcl:"ignore_box_vagrantfile,optional" json:",omitempty"`
+	Networks                   []*Network        `hcl:"network,block" json:",omitempty"`
+	PostUpMessage              *string           `hcl:"post_up_message,optional" json:",omitempty"`
+	Primary                    *bool             `hcl:"primary,optional" json:",omitempty"`
+	Providers                  []*Provider       `hcl:"provider,block" json:",omitempty" merge:"append,key=name"`
+	Provisioners               []*Provisioner    `hcl:"provisioner,block" json:",omitempty"`
+	SyncedFolders              []*SyncedFolder   `hcl:"synced_folder,block" json:",omitempty" merge:"append,key=destination"`
+	UsablePortRange            *Range            `hcl:"usable_port_range,block" json:",omitempty"`
+
+	// Origins records the layer which set each value when
+	// layers are merged, see MergeLayers
+	Origins map[string]*Origin `json:"-" merge:"-"`
+
+	Body   hcl.Body `hcl:",body" json:"-"`
+	Remain hcl.Body `hcl:",remain" json:"-"`
+}
+
+// Merge applies the overlay onto the VM and returns the result. This
+// is how a define_vm block is applied onto the global vm block.
+func (v *VM) Merge(overlay *VM) (*VM, error) {
+	return merge.Structs(v, overlay)
+}
 
 func (v *VM) Target() *Target {
 	return &Target{
-		Name: v.Name,
-		// Provider:  v.Provider,
-		// Providers: v.Providers,
+		Name:      v.Name,
+		Providers: v.Providers,
 	}
 }
 
@@ -128,7 +150,11 @@ type Disk struct {
 }
 
 type Network struct {
-	Kind *string `hcl:"kind,label" json:"kind"`
+	Kind string `hcl:"kind,label" json:"kind"`
+
+	// Typed configuration based on the kind of network
+	ForwardedPort *network.ForwardedPort `json:",omitempty"`
+	Network       *network.Network       `json:",omitempty"`
 
 	Body   hcl.Body `hcl:",body" json:"-"`
 	Remain hcl.Body `hcl:",remain" json:"-"`
@@ -148,6 +174,9 @@ type Plugin struct {
 type Provider struct {
 	Name string `hcl:"name,label" json:"name"`
 
+	// Typed configuration based on the provider name
+	VirtualBox *provider.VirtualBox `json:",omitempty"`
+
 	Body   hcl.Body `hcl:",body" json:"-"`
 	Remain hcl.Body `hcl:",remain" json:"-"`
 }
@@ -155,6 +184,10 @@ type Provider struct {
 type Provisioner struct {
 	Kind string `hcl:"kind,label" json:"kind"`
 
+	// Typed configuration based on the kind of provisioner
+	File  *provisioner.File  `json:",omitempty"`
+	Shell *provisioner.Shell `json:",omitempty"`
+
 	Body   hcl.Body `hcl:",body" json:"-"`
 	Remain hcl.Body `hcl:",remain" json:"-"`
 }
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVM_Merge(t *testing.T) {
+	require := require.New(t)
+
+	v, err := LoadVagrantfile([]byte(`
+vm {
+  box = "hashicorp/bionic64"
+
+  provider "virtualbox" {
+    memory = 1024
+    cpus   = 2
+  }
+
+  synced_folder "." "/vagrant" {}
+}
+
+define_vm "web" {
+  hostname = "web"
+
+  provider "virtualbox" {
+    memory = 2048
+  }
+
+  synced_folder "./src" "/vagrant" {}
+  synced_folder "./data" "/data" {}
+}
+`), "Vagrantfile.hcl", HCL, WithStrict())
+	require.NoError(err)
+
+	vm, err := v.VM.Merge(v.DefinedVms[0])
+	require.NoError(err)
+
+	require.Equal("web", vm.Name)
+	require.Equal("hashicorp/bionic64", *vm.Box)
+	require.Equal("web", *vm.Hostname)
+
+	require.Len(vm.Providers, 1)
+	require.Equal(int32(2048), vm.Providers[0].VirtualBox.Memory)
+	require.Equal(int32(2), vm.Providers[0].VirtualBox.CPUs)
+
+	require.Len(vm.SyncedFolders, 2)
+	require.Equal("./src", vm.SyncedFolders[0].Source)
+	require.Equal("/data", vm.SyncedFolders[1].Destination)
+
+	require.Equal(DefaultVMName, v.VM.Name)
+	require.Len(v.VM.SyncedFolders, 1)
+}
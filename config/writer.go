@@ -0,0 +1,441 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/gocty"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/helper/path"
+)
+
+// HCLVagrantfileName is the name of the file written when
+// migrating a Ruby Vagrantfile
+const HCLVagrantfileName = "Vagrantfile.hcl"
+
+// FormatVagrantfile renders the Vagrantfile as formatted HCL. Content
+// within Remain bodies, like plugin configuration blocks, is included.
+// Expressions are written as their evaluated values, so var and locals
+// blocks are not part of the result.
+func FormatVagrantfile(v *Vagrantfile) ([]byte, error) {
+	w := &hclWriter{ctx: v.EvalContext}
+	if w.ctx == nil {
+		w.ctx = &hcl.EvalContext{}
+	}
+
+	// Blocks are written in the order they are commonly
+	// defined rather than the order of the struct fields
+	f := hclwrite.NewEmptyFile()
+	for _, b := range []struct {
+		name  string
+		value interface{}
+	}{
+		{"vagrant", v.Vagrant},
+		{"ssh", v.SSH},
+		{"communicator", v.Communicators},
+		{"vm", v.VM},
+		{"define_vm", v.DefinedVms},
+	} {
+		if err := w.blocks(b.name, reflect.ValueOf(b.value), f.Body()); err != nil {
+			return nil, err
+		}
+	}
+	if v.Remain != nil {
+		if err := w.remain(v.Remain, f.Body()); err != nil {
+			return nil, err
+		}
+	}
+
+	// The label of the global vm block is set when loaded
+	if v.VM != nil && v.VM.Name == DefaultVMName {
+		if b := f.Body().FirstMatchingBlock("vm", []string{DefaultVMName}); b != nil {
+			b.SetLabels(nil)
+		}
+	}
+
+	return bytes.TrimLeft(hclwrite.Format(f.Bytes()), "\n"), nil
+}
+
+// WriteVagrantfile writes the Vagrantfile as formatted HCL
+func WriteVagrantfile(v *Vagrantfile, w io.Writer) error {
+	content, err := FormatVagrantfile(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(content)
+
+	return err
+}
+
+// MigrateRubyVagrantfile loads a Ruby Vagrantfile and writes it as an
+// HCL Vagrantfile within the same directory. The path of the new file
+// is returned. An existing HCL Vagrantfile will not be overwritten.
+func MigrateRubyVagrantfile(
+	p path.Path, // path to Ruby Vagrantfile
+	rubyRuntime parser, // ruby runtime plugin
+	opts ...LoadOption,
+) (path.Path, error) {
+	dst := p.Dir().Join(HCLVagrantfileName)
+	if dst.Exists() {
+		return nil, fmt.Errorf("HCL Vagrantfile already exists at %s", dst)
+	}
+
+	v, err := LoadRubyVagrantfile(p, rubyRuntime, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := FormatVagrantfile(v)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(dst.String(), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if _, err = f.Write(content); err != nil {
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+type hclWriter struct {
+	ctx *hcl.EvalContext
+}
+
+// body writes the fields of a struct into the body. Untagged struct
+// fields holding typed configuration decoded from the remaining body
+// are written inline, followed by any remaining content which was
+// not already written.
+func (w *hclWriter) body(rv reflect.Value, dst *hclwrite.Body) error {
+	t := rv.Type()
+	var remain hcl.Body
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fv := rv.Field(i)
+		tag, ok := f.Tag.Lookup("hcl")
+		if !ok {
+			if isConfigStruct(f.Type) && !fv.IsNil() {
+				if err := w.body(fv.Elem(), dst); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		name, kind, _ := strings.Cut(tag, ",")
+		switch kind {
+		case "", "optional":
+			// Unset optional values are not written
+			if kind == "optional" && fv.IsZero() {
+				continue
+			}
+			if err := w.attribute(name, fv, dst); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		case "block":
+			if err := w.blocks(name, fv, dst); err != nil {
+				return err
+			}
+		case "remain":
+			if !fv.IsNil() {
+				remain = fv.Interface().(hcl.Body)
+			}
+		}
+	}
+
+	if remain != nil {
+		return w.remain(remain, dst)
+	}
+
+	return nil
+}
+
+// isConfigStruct checks if the type is a pointer to a struct
+// with hcl tags
+func isConfigStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	t = t.Elem()
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("hcl"); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w *hclWriter) attribute(name string, fv reflect.Value, dst *hclwrite.Body) error {
+	switch fv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if fv.IsNil() {
+			return nil
+		}
+		fv = fv.Elem()
+	case reflect.Map, reflect.Slice:
+		if fv.Len() == 0 {
+			return nil
+		}
+	}
+
+	ty, err := gocty.ImpliedType(fv.Interface())
+	if err != nil {
+		return err
+	}
+	val, err := gocty.ToCtyValue(fv.Interface(), ty)
+	if err != nil {
+		return err
+	}
+	dst.SetAttributeValue(name, val)
+
+	return nil
+}
+
+func (w *hclWriter) blocks(name string, fv reflect.Value, dst *hclwrite.Body) error {
+	var items []reflect.Value
+	switch fv.Kind() {
+	case reflect.Slice:
+		for i := 0; i < fv.Len(); i++ {
+			items = append(items, fv.Index(i))
+		}
+	default:
+		items = append(items, fv)
+	}
+
+	for _, item := range items {
+		if item.Kind() == reflect.Ptr {
+			if item.IsNil() {
+				continue
+			}
+			item = item.Elem()
+		}
+
+		var labels []string
+		for i := 0; i < item.NumField(); i++ {
+			if strings.HasSuffix(item.Type().Field(i).Tag.Get("hcl"), ",label") {
+				labels = append(labels, fmt.Sprint(item.Field(i).Interface()))
+			}
+		}
+
+		dst.AppendNewline()
+		block := dst.AppendNewBlock(name, labels)
+		if err := w.body(item, block.Body()); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// remain writes the content of a body which has not been decoded.
+// Attributes already written, and blocks of the types already written
+// from decoded fields, are skipped.
+func (w *hclWriter) remain(body hcl.Body, dst *hclwrite.Body) error {
+	// Typed configuration decoded from the remaining body is written
+	// from its fields, so the blocks it was decoded from are skipped
+	emitted := map[string]bool{}
+	for _, blk := range dst.Blocks() {
+		emitted[blk.Type()] = true
+	}
+
+	return w.remainBody(body, dst, emitted)
+}
+
+func (w *hclWriter) remainBody(body hcl.Body, dst *hclwrite.Body, emitted map[string]bool) error {
+	// The bodies of merged layers are written in order
+	if lb, ok := body.(*layeredBody); ok {
+		for _, b := range lb.bodies {
+			if err := w.remainBody(b, dst, emitted); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Bodies which are not native syntax, like JSON bodies, cannot
+	// tell blocks apart from attributes without a schema
+	b, ok := body.(*hclsyntax.Body)
+	if !ok {
+		return w.remainValues(body, dst, emitted)
+	}
+
+	// Requesting all content ensures only the content which
+	// was not decoded is returned
+	schema := &hcl.BodySchema{}
+	for n := range b.Attributes {
+		schema.Attributes = append(schema.Attributes, hcl.AttributeSchema{Name: n})
+	}
+	seen := map[string]bool{}
+	for _, blk := range b.Blocks {
+		if seen[blk.Type] {
+			continue
+		}
+		seen[blk.Type] = true
+		schema.Blocks = append(schema.Blocks, hcl.BlockHeaderSchema{
+			Type:       blk.Type,
+			LabelNames: make([]string, len(blk.Labels)),
+		})
+	}
+
+	content, _, diags := b.PartialContent(schema)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	attrs := sortedAttributes(content.Attributes)
+	for _, attr := range attrs {
+		if dst.GetAttribute(attr.Name) != nil {
+			continue
+		}
+
+		val, diags := attr.Expr.Value(w.ctx)
+		if diags.HasErrors() {
+			return diags
+		}
+		dst.SetAttributeValue(attr.Name, val)
+	}
+
+	for _, blk := range content.Blocks {
+		if emitted[blk.Type] {
+			continue
+		}
+
+		dst.AppendNewline()
+		nb := dst.AppendNewBlock(blk.Type, blk.Labels)
+		if err := w.remain(blk.Body, nb.Body()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// remainValues writes the content of a body which is not native
+// syntax. Object values are written as blocks and lists of objects
+// as repeated blocks, the way they are expressed within JSON. Block
+// labels cannot be determined so they are written as nested blocks.
+func (w *hclWriter) remainValues(body hcl.Body, dst *hclwrite.Body, emitted map[string]bool) error {
+	all, diags := body.JustAttributes()
+	if diags.HasErrors() {
+		return diags
+	}
+
+	var names []string
+	vals := map[string]cty.Value{}
+	for _, attr := range sortedAttributes(all) {
+		if dst.GetAttribute(attr.Name) != nil || emitted[attr.Name] {
+			continue
+		}
+
+		val, diags := attr.Expr.Value(w.ctx)
+		if diags.HasErrors() {
+			return diags
+		}
+		names = append(names, attr.Name)
+		vals[attr.Name] = val
+	}
+
+	writeValues(names, vals, dst)
+
+	return nil
+}
+
+// writeValues writes the values as attributes, followed by the
+// values which are written as blocks
+func writeValues(names []string, vals map[string]cty.Value, dst *hclwrite.Body) {
+	for _, name := range names {
+		if !isBlockValue(vals[name]) {
+			dst.SetAttributeValue(name, vals[name])
+		}
+	}
+
+	for _, name := range names {
+		val := vals[name]
+		if !isBlockValue(val) {
+			continue
+		}
+
+		bodies := []cty.Value{val}
+		if !isObjectValue(val) {
+			bodies = val.AsValueSlice()
+		}
+
+		for _, bv := range bodies {
+			var keys []string
+			attrs := map[string]cty.Value{}
+			for it := bv.ElementIterator(); it.Next(); {
+				k, v := it.Element()
+				keys = append(keys, k.AsString())
+				attrs[k.AsString()] = v
+			}
+
+			dst.AppendNewline()
+			nb := dst.AppendNewBlock(name, nil)
+			writeValues(keys, attrs, nb.Body())
+		}
+	}
+}
+
+// isBlockValue checks if the value is an object, or a list
+// of objects, which is written as a block
+func isBlockValue(val cty.Value) bool {
+	if isObjectValue(val) {
+		return true
+	}
+
+	ty := val.Type()
+	if val.IsNull() || !val.IsKnown() || !(ty.IsTupleType() || ty.IsListType()) ||
+		val.LengthInt() == 0 {
+		return false
+	}
+
+	for _, v := range val.AsValueSlice() {
+		if !isObjectValue(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isObjectValue(val cty.Value) bool {
+	ty := val.Type()
+	return !val.IsNull() && val.IsKnown() && (ty.IsObjectType() || ty.IsMapType())
+}
+
+// sortedAttributes returns the attributes in the order
+// they are defined
+func sortedAttributes(all hcl.Attributes) []*hcl.Attribute {
+	attrs := make([]*hcl.Attribute, 0, len(all))
+	for _, attr := range all {
+		attrs = append(attrs, attr)
+	}
+
+	sort.Slice(attrs, func(i, j int) bool {
+		if attrs[i].Range.Start.Byte != attrs[j].Range.Start.Byte {
+			return attrs[i].Range.Start.Byte < attrs[j].Range.Start.Byte
+		}
+		return attrs[i].Name < attrs[j].Name
+	})
+
+	return attrs
+}
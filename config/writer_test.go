@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/helper/path"
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+)
+
+func TestFormatVagrantfile(t *testing.T) {
+	require := require.New(t)
+
+	v, err := LoadVagrantfile([]byte(`
+locals {
+  memory = 1024
+}
+
+vm {
+  box = "hashicorp/bionic64"
+
+  provider "virtualbox" {
+    memory = local.memory * 2
+  }
+
+  provider "libvirt" {
+    memory = local.memory
+  }
+
+  trigger "up" {
+    info = "starting"
+  }
+}
+
+define_vm "web" {
+  primary = true
+}
+`), "Vagrantfile.hcl", HCL, WithStrict())
+	require.NoError(err)
+
+	content, err := FormatVagrantfile(v)
+	require.NoError(err)
+
+	out := string(content)
+	require.True(strings.HasPrefix(out, "vm {\n"), out)
+	require.Contains(out, `box = "hashicorp/bionic64"`)
+	require.Contains(out, "provider \"virtualbox\" {\n    memory = 2048")
+	require.Contains(out, "provider \"libvirt\" {\n    memory = 1024")
+	require.Contains(out, "trigger \"up\" {\n    info = \"starting\"")
+	require.Contains(out, `define_vm "web"`)
+	require.NotContains(out, "locals")
+
+	// The written Vagrantfile loads to the same configuration
+	result, err := LoadVagrantfile(content, "Vagrantfile.hcl", HCL, WithStrict())
+	require.NoError(err, out)
+	require.Equal(*v.VM.Box, *result.VM.Box)
+	require.Equal(int32(2048), result.VM.Providers[0].VirtualBox.Memory)
+	require.Equal(v.TargetNames(), result.TargetNames())
+}
+
+func TestFormatVagrantfile_repeatedBlocks(t *testing.T) {
+	require := require.New(t)
+
+	v, err := LoadVagrantfile([]byte(`
+vm {
+  trigger {
+    info = "first"
+  }
+
+  trigger {
+    info = "second"
+  }
+}
+
+myplugin {
+  name = "first"
+}
+
+myplugin {
+  name = "second"
+}
+`), "Vagrantfile.hcl", HCL)
+	require.NoError(err)
+
+	content, err := FormatVagrantfile(v)
+	require.NoError(err)
+
+	out := string(content)
+	require.Equal(2, strings.Count(out, "trigger {"), out)
+	require.Contains(out, `info = "second"`)
+	require.Equal(2, strings.Count(out, "myplugin {"), out)
+	require.Contains(out, `name = "second"`)
+}
+
+func TestFormatVagrantfile_json(t *testing.T) {
+	require := require.New(t)
+
+	v, err := LoadVagrantfile([]byte(`{
+  "vm": {
+    "default": {
+      "box": "hashicorp/bionic64"
+    }
+  },
+  "myplugin": {
+    "enabled": true,
+    "options": {
+      "level": 2
+    },
+    "hook": [
+      {"name": "first"},
+      {"name": "second"}
+    ]
+  }
+}`), "Vagrantfile.json", JSON)
+	require.NoError(err)
+
+	content, err := FormatVagrantfile(v)
+	require.NoError(err)
+
+	// The written Vagrantfile loads with the plugin configuration
+	// as a block
+	result, err := LoadVagrantfile(content, "Vagrantfile.hcl", HCL, WithStrict())
+	require.NoError(err, string(content))
+	require.Equal("hashicorp/bionic64", *result.VM.Box)
+
+	pc, _, diags := result.Remain.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "myplugin"}},
+	})
+	require.False(diags.HasErrors(), diags.Error())
+	require.Len(pc.Blocks, 1)
+
+	var plugin struct {
+		Enabled bool `hcl:"enabled"`
+		Options struct {
+			Level int `hcl:"level"`
+		} `hcl:"options,block"`
+		Hooks []struct {
+			Name string `hcl:"name"`
+		} `hcl:"hook,block"`
+	}
+	diags = gohcl.DecodeBody(pc.Blocks[0].Body, nil, &plugin)
+	require.False(diags.HasErrors(), diags.Error())
+	require.True(plugin.Enabled)
+	require.Equal(2, plugin.Options.Level)
+	require.Len(plugin.Hooks, 2)
+	require.Equal("second", plugin.Hooks[1].Name)
+}
+
+type testParser struct {
+	json []byte
+}
+
+func (p *testParser) ParseVagrantfile(string) (*vagrant_plugin_sdk.Vagrantfile_Serialized, error) {
+	return &vagrant_plugin_sdk.Vagrantfile_Serialized{Json: p.json}, nil
+}
+
+func TestMigrateRubyVagrantfile(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := os.MkdirTemp("", "configtest")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	p := &testParser{json: []byte(`{
+  "vm": {
+    "default": {
+      "box": "hashicorp/bionic64",
+      "provider": {
+        "libvirt": {
+          "memory": 1024
+        }
+      }
+    }
+  }
+}`)}
+
+	dst, err := MigrateRubyVagrantfile(path.NewPath(filepath.Join(dir, "Vagrantfile")), p)
+	require.NoError(err)
+	require.Equal(filepath.Join(dir, HCLVagrantfileName), dst.String())
+
+	v, err := LoadHCLVagrantfile(dst)
+	require.NoError(err)
+	require.Equal("hashicorp/bionic64", *v.VM.Box)
+	require.Len(v.VM.Providers, 1)
+	require.Equal("libvirt", v.VM.Providers[0].Name)
+
+	// Existing files are not overwritten
+	_, err = MigrateRubyVagrantfile(path.NewPath(filepath.Join(dir, "Vagrantfile")), p)
+	require.Error(err)
+}
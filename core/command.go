@@ -10,6 +10,8 @@ import (
 )
 
 type Command interface {
+	component.Documented
+
 	CommandInfo() (*component.CommandInfo, error)
 	Execute([]string) (int32, error)
 
@@ -3,6 +3,8 @@
 
 package core
 
+import "github.com/hashicorp/vagrant-plugin-sdk/component"
+
 type CommunicatorMessage struct {
 	ExitCode int32
 	Stdout   string
@@ -10,10 +12,10 @@ type CommunicatorMessage struct {
 }
 
 type Communicator interface {
+	component.Documented
 	Seeder
 
 	// Config() interface{}
-	// Documentation() (*docs.Documentation, error)
 	Download(machine Machine, source, destination string) error
 	Execute(machine Machine, command []string, opts ...interface{}) (status int32, err error)
 	Init(machine Machine) error
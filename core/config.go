@@ -6,6 +6,8 @@ package core
 import "github.com/hashicorp/vagrant-plugin-sdk/component"
 
 type Config interface {
+	component.Documented
+
 	Init(*component.ConfigData) (*component.ConfigData, error)
 	Register() (*component.ConfigRegistration, error)
 	Struct() (interface{}, error)
@@ -3,7 +3,10 @@
 
 package core
 
+import "github.com/hashicorp/vagrant-plugin-sdk/component"
+
 type Downloader interface {
+	component.Documented
 	// component.Configurable
 
 	Download() error
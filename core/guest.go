@@ -5,9 +5,12 @@ package core
 
 import (
 	"io"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
 )
 
 type Guest interface {
+	component.Documented
 	CapabilityPlatform
 	Seeder
 	Named
@@ -4,7 +4,7 @@ package mocks
 
 import (
 	component "github.com/hashicorp/vagrant-plugin-sdk/component"
-
+	docs "github.com/hashicorp/vagrant-plugin-sdk/docs"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -13,6 +13,32 @@ type Config struct {
 	mock.Mock
 }
 
+// Documentation provides a mock function with given fields:
+func (_m *Config) Documentation() (*docs.Documentation, error) {
+	ret := _m.Called()
+
+	var r0 *docs.Documentation
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*docs.Documentation, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *docs.Documentation); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*docs.Documentation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Finalize provides a mock function with given fields: _a0
 func (_m *Config) Finalize(_a0 *component.ConfigData) (*component.ConfigData, error) {
 	ret := _m.Called(_a0)
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	core "github.com/hashicorp/vagrant-plugin-sdk/core"
+	docs "github.com/hashicorp/vagrant-plugin-sdk/docs"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -58,6 +59,32 @@ func (_m *Provider) Capability(name string, args ...interface{}) (interface{}, e
 	return r0, r1
 }
 
+// Documentation provides a mock function with given fields:
+func (_m *Provider) Documentation() (*docs.Documentation, error) {
+	ret := _m.Called()
+
+	var r0 *docs.Documentation
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*docs.Documentation, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *docs.Documentation); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*docs.Documentation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HasCapability provides a mock function with given fields: name
 func (_m *Provider) HasCapability(name string) (bool, error) {
 	ret := _m.Called(name)
@@ -5,7 +5,7 @@ package mocks
 import (
 	component "github.com/hashicorp/vagrant-plugin-sdk/component"
 	core "github.com/hashicorp/vagrant-plugin-sdk/core"
-
+	docs "github.com/hashicorp/vagrant-plugin-sdk/docs"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -42,6 +42,32 @@ func (_m *Provisioner) Configure(machine core.Machine, config *component.ConfigD
 	return r0
 }
 
+// Documentation provides a mock function with given fields:
+func (_m *Provisioner) Documentation() (*docs.Documentation, error) {
+	ret := _m.Called()
+
+	var r0 *docs.Documentation
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*docs.Documentation, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *docs.Documentation); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*docs.Documentation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Provision provides a mock function with given fields: machine, config
 func (_m *Provisioner) Provision(machine core.Machine, config *component.ConfigData) error {
 	ret := _m.Called(machine, config)
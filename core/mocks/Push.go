@@ -2,13 +2,42 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	docs "github.com/hashicorp/vagrant-plugin-sdk/docs"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // Push is an autogenerated mock type for the Push type
 type Push struct {
 	mock.Mock
 }
 
+// Documentation provides a mock function with given fields:
+func (_m *Push) Documentation() (*docs.Documentation, error) {
+	ret := _m.Called()
+
+	var r0 *docs.Documentation
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*docs.Documentation, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *docs.Documentation); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*docs.Documentation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Push provides a mock function with given fields:
 func (_m *Push) Push() error {
 	ret := _m.Called()
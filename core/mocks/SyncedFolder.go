@@ -4,6 +4,7 @@ package mocks
 
 import (
 	core "github.com/hashicorp/vagrant-plugin-sdk/core"
+	docs "github.com/hashicorp/vagrant-plugin-sdk/docs"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -75,6 +76,32 @@ func (_m *SyncedFolder) Disable(machine core.Machine, folders []*core.Folder, op
 	return r0
 }
 
+// Documentation provides a mock function with given fields:
+func (_m *SyncedFolder) Documentation() (*docs.Documentation, error) {
+	ret := _m.Called()
+
+	var r0 *docs.Documentation
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*docs.Documentation, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *docs.Documentation); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*docs.Documentation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Enable provides a mock function with given fields: machine, folders, opts
 func (_m *SyncedFolder) Enable(machine core.Machine, folders []*core.Folder, opts ...interface{}) error {
 	var _ca []interface{}
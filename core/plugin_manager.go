@@ -10,6 +10,7 @@ type NamedPlugin struct {
 	Name    string
 	Type    string
 	Options interface{}
+	Version string
 }
 
 type PluginManager interface {
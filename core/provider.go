@@ -3,6 +3,8 @@
 
 package core
 
+import "github.com/hashicorp/vagrant-plugin-sdk/component"
+
 type SshInfo struct {
 	Host           *string
 	Port           *string
@@ -11,6 +13,7 @@ type SshInfo struct {
 }
 
 type Provider interface {
+	component.Documented
 	CapabilityPlatform
 
 	Usable() (bool, error)
@@ -8,6 +8,8 @@ import (
 )
 
 type Provisioner interface {
+	component.Documented
+
 	Provision(machine Machine, config *component.ConfigData) (err error)
 	Configure(machine Machine, config *component.ConfigData, rootConfig *component.ConfigData) (err error)
 	Cleanup(machine Machine, config *component.ConfigData) (err error)
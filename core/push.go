@@ -3,6 +3,10 @@
 
 package core
 
+import "github.com/hashicorp/vagrant-plugin-sdk/component"
+
 type Push interface {
+	component.Documented
+
 	Push() (err error)
 }
@@ -4,6 +4,7 @@
 package core
 
 import (
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
 	"github.com/hashicorp/vagrant-plugin-sdk/helper/path"
 )
 
@@ -14,6 +15,7 @@ type Folder struct {
 }
 
 type SyncedFolder interface {
+	component.Documented
 	CapabilityPlatform
 	Seeder
 
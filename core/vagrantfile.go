@@ -19,3 +19,10 @@ type Vagrantfile interface {
 	TargetNames() (names []string, err error)
 	//TargetNamesAndOptions() (names []string, options map[string]interface{}, err error)
 }
+
+// VagrantfileProvenance is optionally implemented by a Vagrantfile
+// which records where its values were set
+type VagrantfileProvenance interface {
+	// Returns where the value at the given path was set
+	GetValueProvenance(path ...string) (*component.Provenance, error)
+}
@@ -4,6 +4,10 @@
 package datadir
 
 import (
+	"fmt"
+	"os"
+	"time"
+
 	"github.com/hashicorp/vagrant-plugin-sdk/helper/path"
 )
 
@@ -32,3 +36,28 @@ func (m *Target) Component(typ, name string) (*Component, error) {
 
 	return &Component{Dir: dir}, nil
 }
+
+// LogDir returns the path to a folder for storing logs of the target,
+// like a log of the output of each command. The folder is created if
+// it does not exist.
+func (m *Target) LogDir() (path.Path, error) {
+	dir := m.DataDir().Join("logs")
+	if err := os.MkdirAll(dir.String(), 0755); err != nil {
+		return nil, err
+	}
+
+	return dir, nil
+}
+
+// CreateLog creates a new file within the log directory for logging a
+// run of the command, like "up". The file is named using the command
+// and the current time so each run has its own log.
+func (m *Target) CreateLog(command string) (*os.File, error) {
+	dir, err := m.LogDir()
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("%s-%s.log", command, time.Now().UTC().Format("20060102T150405.000000000"))
+	return os.Create(dir.Join(name).String())
+}
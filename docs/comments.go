@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package docs
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	commentsLock sync.RWMutex
+	comments     = map[reflect.Type]map[string]string{}
+)
+
+// RegisterComments registers the doc comments of the fields of the
+// struct type of v, keyed by Go field name. The comments are used
+// by FromConfig for fields which do not set a doc tag. This is
+// generally called from the init function generated by docgen:
+//
+//	//go:generate go run github.com/hashicorp/vagrant-plugin-sdk/docs/docgen -type Config
+func RegisterComments(v interface{}, fields map[string]string) {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	commentsLock.Lock()
+	defer commentsLock.Unlock()
+
+	comments[t] = fields
+}
+
+// fieldComment returns the registered comment for the field of the
+// struct type. The first paragraph of the comment is returned as
+// the synopsis and any remaining paragraphs as the summary.
+func fieldComment(t reflect.Type, name string) (synopsis, summary string) {
+	commentsLock.RLock()
+	defer commentsLock.RUnlock()
+
+	c := strings.TrimSpace(comments[t][name])
+	if c == "" {
+		return "", ""
+	}
+
+	synopsis, summary, _ = strings.Cut(c, "\n\n")
+
+	return strings.Join(strings.Fields(synopsis), " "), strings.TrimSpace(summary)
+}
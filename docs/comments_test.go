@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package docs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type commentedNetwork struct {
+	Type string `hcl:"type,attr"`
+}
+
+type commentedConfig struct {
+	Name    string            `hcl:"name,attr" doc:"Name of the machine"`
+	Memory  int               `hcl:"memory,optional" default:"512" env:"TEST_MEMORY"`
+	Network *commentedNetwork `hcl:"network,block"`
+}
+
+func TestFromConfig_comments(t *testing.T) {
+	require := require.New(t)
+
+	RegisterComments((*commentedConfig)(nil), map[string]string{
+		"Name":   "Ignored since the doc tag is set",
+		"Memory": "Memory of the\nmachine.\n\nDefaults to 512 MB.",
+	})
+	RegisterComments((*commentedNetwork)(nil), map[string]string{
+		"Type": "Type of network",
+	})
+
+	d, err := New(FromConfig(&commentedConfig{}))
+	require.NoError(err)
+
+	fields := d.Fields()
+	require.Len(fields, 3)
+
+	memory := fields[0]
+	require.Equal("Memory of the machine.", memory.Synopsis)
+	require.Equal("Defaults to 512 MB.", memory.Summary)
+	require.Equal("512", memory.Default)
+	require.Equal("TEST_MEMORY", memory.EnvVar)
+
+	require.Equal("Name of the machine", fields[1].Synopsis)
+	require.Equal("Type of network", fields[2].SubField("type").Synopsis)
+}
@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Docgen generates a file registering the doc comments of the fields
+// of configuration structs with the docs package, so the comments are
+// used by docs.FromConfig. It is intended to be run by go generate:
+//
+//	//go:generate go run github.com/hashicorp/vagrant-plugin-sdk/docs/docgen
+//
+// By default all structs within the package with hcl tagged fields are
+// included. The -type flag limits the structs to a comma separated list.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const defaultOutput = "docs_generated.go"
+
+func main() {
+	typeNames := flag.String("type", "", "comma separated list of struct names; default all structs with hcl tags")
+	output := flag.String("output", defaultOutput, "output file name")
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	var types []string
+	if *typeNames != "" {
+		types = strings.Split(*typeNames, ",")
+	}
+
+	src, err := generate(dir, *output, types, os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "docgen: %s\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, *output), src, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "docgen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// structDocs holds the field comments of a struct
+type structDocs struct {
+	name   string
+	fields map[string]string
+}
+
+// generate parses the package within dir and returns the source
+// of the registration file. Test files and the output file are
+// not parsed.
+func generate(dir, output string, types, args []string) ([]byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != output
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected one package in %s, found %d", dir, len(pkgs))
+	}
+
+	var pkg *ast.Package
+	for _, p := range pkgs {
+		pkg = p
+	}
+
+	found := map[string]*structDocs{}
+	for _, f := range pkg.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			ts, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				return true
+			}
+			if d := fieldComments(st); d != nil {
+				d.name = ts.Name.Name
+				found[d.name] = d
+			}
+			return false
+		})
+	}
+
+	var result []*structDocs
+	if len(types) == 0 {
+		for _, d := range found {
+			if len(d.fields) > 0 {
+				result = append(result, d)
+			}
+		}
+	} else {
+		for _, name := range types {
+			d, ok := found[strings.TrimSpace(name)]
+			if !ok {
+				return nil, fmt.Errorf("no documented struct named %q", name)
+			}
+			result = append(result, d)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].name < result[j].name
+	})
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by \"docgen %s\"; DO NOT EDIT.\n\n", strings.Join(args, " "))
+	fmt.Fprintf(&buf, "package %s\n\n", pkg.Name)
+	buf.WriteString("import \"github.com/hashicorp/vagrant-plugin-sdk/docs\"\n\n")
+	buf.WriteString("func init() {\n")
+	for _, d := range result {
+		names := make([]string, 0, len(d.fields))
+		for name := range d.fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Fprintf(&buf, "docs.RegisterComments((*%s)(nil), map[string]string{\n", d.name)
+		for _, name := range names {
+			fmt.Fprintf(&buf, "%q: %s,\n", name, strconv.Quote(d.fields[name]))
+		}
+		buf.WriteString("})\n")
+	}
+	buf.WriteString("}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// fieldComments returns the comments of the hcl tagged fields within
+// the struct. Doc comments are preferred over line comments. Nil is
+// returned if the struct has no hcl tagged fields.
+func fieldComments(st *ast.StructType) *structDocs {
+	tagged := false
+	result := &structDocs{fields: map[string]string{}}
+	for _, f := range st.Fields.List {
+		if f.Tag == nil {
+			continue
+		}
+		tag, err := strconv.Unquote(f.Tag.Value)
+		if err != nil {
+			continue
+		}
+		if _, ok := reflect.StructTag(tag).Lookup("hcl"); !ok {
+			continue
+		}
+		tagged = true
+
+		comment := strings.TrimSpace(f.Doc.Text())
+		if comment == "" {
+			comment = strings.TrimSpace(f.Comment.Text())
+		}
+		if comment == "" {
+			continue
+		}
+		for _, n := range f.Names {
+			result.fields[n.Name] = comment
+		}
+	}
+	if !tagged {
+		return nil
+	}
+
+	return result
+}
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testSource = `package example
+
+type Config struct {
+	// Name of the machine.
+	//
+	// Must be unique within the project.
+	Name string ` + "`hcl:\"name,attr\"`" + `
+
+	Memory int ` + "`hcl:\"memory,optional\"`" + ` // Memory in MB
+
+	Network *Network ` + "`hcl:\"network,block\"`" + `
+
+	internal string
+}
+
+type Network struct {
+	// Type of the network
+	Type string ` + "`hcl:\"type,attr\"`" + `
+}
+
+type Undocumented struct {
+	Value string ` + "`hcl:\"value\"`" + `
+}
+
+type Plain struct {
+	// Not configuration
+	Value string
+}
+`
+
+func TestGenerate(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	require.NoError(os.WriteFile(filepath.Join(dir, "config.go"), []byte(testSource), 0644))
+	require.NoError(os.WriteFile(filepath.Join(dir, defaultOutput), []byte("package broken {"), 0644))
+
+	src, err := generate(dir, defaultOutput, nil, []string{"-output", defaultOutput})
+	require.NoError(err)
+	require.Equal(`// Code generated by "docgen -output docs_generated.go"; DO NOT EDIT.
+
+package example
+
+import "github.com/hashicorp/vagrant-plugin-sdk/docs"
+
+func init() {
+	docs.RegisterComments((*Config)(nil), map[string]string{
+		"Memory": "Memory in MB",
+		"Name":   "Name of the machine.\n\nMust be unique within the project.",
+	})
+	docs.RegisterComments((*Network)(nil), map[string]string{
+		"Type": "Type of the network",
+	})
+}
+`, string(src))
+
+	src, err = generate(dir, defaultOutput, []string{"Undocumented"}, nil)
+	require.NoError(err)
+	require.Contains(string(src), "docs.RegisterComments((*Undocumented)(nil), map[string]string{})")
+
+	_, err = generate(dir, defaultOutput, []string{"Plain"}, nil)
+	require.Error(err)
+}
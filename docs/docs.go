@@ -35,6 +35,23 @@ type FieldDocs struct {
 	Optional bool
 	Default  string
 	EnvVar   string
+
+	// Block is set when the field is a configuration block. The
+	// fields of the block are documented within Fields.
+	Block  bool
+	Fields []*FieldDocs
+}
+
+// SubField returns the documentation for the nested field with
+// the given name
+func (f *FieldDocs) SubField(name string) *FieldDocs {
+	for _, sf := range f.Fields {
+		if sf.Field == name {
+			return sf
+		}
+	}
+
+	return nil
 }
 
 type Documentation struct {
@@ -74,33 +91,77 @@ func FromConfig(v interface{}) Option {
 
 		for i := 0; i < t.NumField(); i++ {
 			f := t.Field(i)
-			name, ok := f.Tag.Lookup("hcl")
-			if !ok {
+			if _, ok := f.Tag.Lookup("hcl"); !ok {
 				return fmt.Errorf("missing hcl tag on field: %s", f.Name)
 			}
 
-			parts := strings.Split(name, ",")
-
-			if parts[0] == "" {
-				continue
+			if field := fieldDocs(t, f); field != nil {
+				d.fields[field.Field] = field
 			}
+		}
 
-			field := &FieldDocs{
-				Field: parts[0],
-				Type:  f.Type.String(),
-			}
+		return nil
+	}
+}
 
-			for _, p := range parts[1:] {
-				if p == "optional" {
-					field.Optional = true
-				}
-			}
+// fieldDocs builds the documentation for a field of the parent
+// struct using its hcl tag. The synopsis, default and environment
+// variable are set from the doc, default and env tags. When the doc
+// tag is not set, the comment registered for the field is used. The
+// fields of blocks are documented as nested fields. Fields without
+// a name are not documented.
+func fieldDocs(parent reflect.Type, f reflect.StructField) *FieldDocs {
+	parts := strings.Split(f.Tag.Get("hcl"), ",")
+	if parts[0] == "" {
+		return nil
+	}
+
+	field := &FieldDocs{
+		Field:    parts[0],
+		Type:     f.Type.String(),
+		Synopsis: f.Tag.Get("doc"),
+		Default:  f.Tag.Get("default"),
+		EnvVar:   f.Tag.Get("env"),
+	}
+	if field.Synopsis == "" {
+		field.Synopsis, field.Summary = fieldComment(parent, f.Name)
+	}
 
-			d.fields[parts[0]] = field
+	for _, p := range parts[1:] {
+		switch p {
+		case "optional":
+			field.Optional = true
+		case "block":
+			field.Block = true
 		}
+	}
 
-		return nil
+	if !field.Block {
+		return field
+	}
+
+	// Blocks which may be omitted or repeated are optional
+	t := f.Type
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		field.Optional = true
+		t = t.Elem()
 	}
+	if t.Kind() != reflect.Struct {
+		return field
+	}
+
+	// Untagged fields within blocks are not configurable
+	// so they are skipped
+	for i := 0; i < t.NumField(); i++ {
+		if sf := fieldDocs(t, t.Field(i)); sf != nil {
+			field.Fields = append(field.Fields, sf)
+		}
+	}
+	sort.Slice(field.Fields, func(i, j int) bool {
+		return field.Fields[i].Field < field.Fields[j].Field
+	})
+
+	return field
 }
 
 func formatHelp(lines ...string) string {
@@ -174,17 +235,26 @@ func (d *Documentation) AddMapper(input, output, description string) {
 	})
 }
 
+// SetField sets the documentation for the field. Fields within
+// blocks are named using the path to the field separated by ".",
+// like "network.type".
 func (d *Documentation) SetField(name, synposis string, opts ...DocOption) error {
-	field, ok := d.fields[name]
+	path := strings.Split(name, ".")
+	field, ok := d.fields[path[0]]
 	if !ok {
-		field = &FieldDocs{
-			Field:    name,
-			Synopsis: synposis,
+		field = &FieldDocs{Field: path[0]}
+		d.fields[path[0]] = field
+	}
+	for _, p := range path[1:] {
+		sf := field.SubField(p)
+		if sf == nil {
+			sf = &FieldDocs{Field: p}
+			field.Block = true
+			field.Fields = append(field.Fields, sf)
 		}
-		d.fields[name] = field
-	} else {
-		field.Synopsis = synposis
+		field = sf
 	}
+	field.Synopsis = synposis
 
 	for _, o := range opts {
 		switch v := o.(type) {
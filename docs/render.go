@@ -0,0 +1,355 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Format is an output format for rendered documentation
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatMan      Format = "man"
+	FormatJSON     Format = "json"
+)
+
+// Page is the documentation of a single component
+type Page struct {
+	Plugin string // Name of the plugin providing the component
+	Type   string // Type of the component, like "provider"
+	Name   string // Name of the component
+	Docs   *Documentation
+}
+
+// Title returns the title of the page
+func (p *Page) Title() string {
+	if p.Type == "" {
+		return p.Name
+	}
+
+	return fmt.Sprintf("%s %s", p.Name, p.Type)
+}
+
+// Filename returns the name of the file the page is written to
+// for the given format
+func (p *Page) Filename(f Format) string {
+	name := p.Name
+	if p.Type != "" {
+		name = fmt.Sprintf("%s-%s", p.Name, p.Type)
+	}
+	name = strings.ToLower(strings.ReplaceAll(name, " ", "-"))
+
+	switch f {
+	case FormatMan:
+		return name + ".7"
+	case FormatJSON:
+		return name + ".json"
+	default:
+		return name + ".md"
+	}
+}
+
+// Render writes the page to w using the given format
+func Render(w io.Writer, f Format, p *Page) error {
+	switch f {
+	case FormatMarkdown:
+		return Markdown(w, p)
+	case FormatMan:
+		return Man(w, p)
+	case FormatJSON:
+		return JSON(w, p)
+	default:
+		return fmt.Errorf("unsupported documentation format %q", f)
+	}
+}
+
+// Generate renders each page into its own file within dir. The
+// directory is created if it does not exist.
+func Generate(dir string, f Format, pages ...*Page) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, p := range pages {
+		var buf bytes.Buffer
+		if err := Render(&buf, f, p); err != nil {
+			return fmt.Errorf("failed to render %s: %w", p.Title(), err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, p.Filename(f)), buf.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Markdown writes the page as a Markdown reference page
+func Markdown(w io.Writer, p *Page) error {
+	d := p.Docs.Details()
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "# %s\n", p.Title())
+	if p.Plugin != "" {
+		fmt.Fprintf(&buf, "\nProvided by the `%s` plugin.\n", p.Plugin)
+	}
+	if d.Description != "" {
+		fmt.Fprintf(&buf, "\n%s\n", strings.TrimSpace(d.Description))
+	}
+	if d.Example != "" {
+		fmt.Fprintf(&buf, "\n## Example\n\n```hcl\n%s\n```\n", strings.Trim(d.Example, "\n"))
+	}
+	if d.Input != "" || d.Output != "" {
+		buf.WriteString("\n## Input and Output\n\n")
+		if d.Input != "" {
+			fmt.Fprintf(&buf, "- Input: `%s`\n", d.Input)
+		}
+		if d.Output != "" {
+			fmt.Fprintf(&buf, "- Output: `%s`\n", d.Output)
+		}
+	}
+
+	if fields := p.Docs.Fields(); len(fields) > 0 {
+		buf.WriteString("\n## Configuration\n")
+		markdownFields(&buf, "", fields)
+	}
+
+	if len(d.Mappers) > 0 {
+		buf.WriteString("\n## Mappers\n\n| Input | Output | Description |\n| --- | --- | --- |\n")
+		for _, m := range d.Mappers {
+			fmt.Fprintf(&buf, "| `%s` | `%s` | %s |\n", m.Input, m.Output, markdownCell(m.Description))
+		}
+	}
+
+	_, err := w.Write(buf.Bytes())
+
+	return err
+}
+
+func markdownFields(buf *bytes.Buffer, prefix string, fields []*FieldDocs) {
+	for _, f := range fields {
+		name := f.Field
+		if prefix != "" {
+			name = prefix + "." + f.Field
+		}
+
+		fmt.Fprintf(buf, "\n### `%s`\n\n", name)
+		var attrs []string
+		if f.Block {
+			attrs = append(attrs, "block")
+		} else if f.Type != "" {
+			attrs = append(attrs, fmt.Sprintf("`%s`", f.Type))
+		}
+		if f.Optional {
+			attrs = append(attrs, "optional")
+		} else {
+			attrs = append(attrs, "required")
+		}
+		fmt.Fprintf(buf, "_%s_\n", strings.Join(attrs, ", "))
+
+		if f.Synopsis != "" {
+			fmt.Fprintf(buf, "\n%s\n", f.Synopsis)
+		}
+		if f.Summary != "" {
+			fmt.Fprintf(buf, "\n%s\n", f.Summary)
+		}
+		if f.Default != "" || f.EnvVar != "" {
+			buf.WriteString("\n")
+			if f.Default != "" {
+				fmt.Fprintf(buf, "- Default: `%s`\n", f.Default)
+			}
+			if f.EnvVar != "" {
+				fmt.Fprintf(buf, "- Environment variable: `%s`\n", f.EnvVar)
+			}
+		}
+
+		markdownFields(buf, name, f.Fields)
+	}
+}
+
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
+
+// Man writes the page as a roff man page in section 7
+func Man(w io.Writer, p *Page) error {
+	d := p.Docs.Details()
+	var buf bytes.Buffer
+
+	title := strings.ToUpper(strings.ReplaceAll(p.Title(), " ", "-"))
+	fmt.Fprintf(&buf, ".TH %s 7 \"\" \"%s\" \"Vagrant Plugin Reference\"\n", title, roffEscape(p.Plugin))
+	buf.WriteString(".SH NAME\n")
+	if p.Type != "" {
+		fmt.Fprintf(&buf, "%s \\- %s %s\n", roffEscape(p.Name), roffEscape(p.Name), roffEscape(p.Type))
+	} else {
+		fmt.Fprintf(&buf, "%s\n", roffEscape(p.Name))
+	}
+	if d.Description != "" {
+		fmt.Fprintf(&buf, ".SH DESCRIPTION\n%s\n", roffText(d.Description))
+	}
+	if d.Example != "" {
+		fmt.Fprintf(&buf, ".SH EXAMPLE\n.nf\n%s\n.fi\n", roffText(strings.Trim(d.Example, "\n")))
+	}
+	if d.Input != "" || d.Output != "" {
+		buf.WriteString(".SH INPUT AND OUTPUT\n")
+		if d.Input != "" {
+			fmt.Fprintf(&buf, ".TP\n.B Input\n%s\n", roffEscape(d.Input))
+		}
+		if d.Output != "" {
+			fmt.Fprintf(&buf, ".TP\n.B Output\n%s\n", roffEscape(d.Output))
+		}
+	}
+
+	if fields := p.Docs.Fields(); len(fields) > 0 {
+		buf.WriteString(".SH CONFIGURATION\n")
+		manFields(&buf, fields)
+	}
+
+	if len(d.Mappers) > 0 {
+		buf.WriteString(".SH MAPPERS\n")
+		for _, m := range d.Mappers {
+			fmt.Fprintf(&buf, ".TP\n.B %s \\(-> %s\n%s\n",
+				roffEscape(m.Input), roffEscape(m.Output), roffText(m.Description))
+		}
+	}
+
+	_, err := w.Write(buf.Bytes())
+
+	return err
+}
+
+func manFields(buf *bytes.Buffer, fields []*FieldDocs) {
+	for _, f := range fields {
+		var attrs []string
+		if f.Block {
+			attrs = append(attrs, "block")
+		} else if f.Type != "" {
+			attrs = append(attrs, f.Type)
+		}
+		if f.Optional {
+			attrs = append(attrs, "optional")
+		} else {
+			attrs = append(attrs, "required")
+		}
+
+		fmt.Fprintf(buf, ".TP\n.B %s\n(%s)", roffEscape(f.Field), roffEscape(strings.Join(attrs, ", ")))
+		if f.Synopsis != "" {
+			fmt.Fprintf(buf, "\n%s", roffText(f.Synopsis))
+		}
+		buf.WriteString("\n")
+		if f.Summary != "" {
+			fmt.Fprintf(buf, ".IP\n%s\n", roffText(f.Summary))
+		}
+		if f.Default != "" {
+			fmt.Fprintf(buf, ".IP\nDefault: %s\n", roffEscape(f.Default))
+		}
+		if f.EnvVar != "" {
+			fmt.Fprintf(buf, ".IP\nEnvironment variable: %s\n", roffEscape(f.EnvVar))
+		}
+
+		if len(f.Fields) > 0 {
+			buf.WriteString(".RS\n")
+			manFields(buf, f.Fields)
+			buf.WriteString(".RE\n")
+		}
+	}
+}
+
+// roffEscape escapes characters which have meaning within roff
+func roffEscape(s string) string {
+	return strings.ReplaceAll(s, "\\", "\\e")
+}
+
+// roffText escapes text spanning multiple lines. Lines starting
+// with a control character are prefixed so they are not treated
+// as requests.
+func roffText(s string) string {
+	lines := strings.Split(roffEscape(strings.TrimSpace(s)), "\n")
+	for i, l := range lines {
+		if strings.HasPrefix(l, ".") || strings.HasPrefix(l, "'") {
+			lines[i] = "\\&" + l
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type jsonPage struct {
+	Plugin      string       `json:"plugin,omitempty"`
+	Type        string       `json:"type,omitempty"`
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Example     string       `json:"example,omitempty"`
+	Input       string       `json:"input,omitempty"`
+	Output      string       `json:"output,omitempty"`
+	Fields      []*jsonField `json:"fields,omitempty"`
+	Mappers     []jsonMapper `json:"mappers,omitempty"`
+}
+
+type jsonField struct {
+	Name     string       `json:"name"`
+	Type     string       `json:"type,omitempty"`
+	Synopsis string       `json:"synopsis,omitempty"`
+	Summary  string       `json:"summary,omitempty"`
+	Optional bool         `json:"optional"`
+	Default  string       `json:"default,omitempty"`
+	EnvVar   string       `json:"env_var,omitempty"`
+	Block    bool         `json:"block,omitempty"`
+	Fields   []*jsonField `json:"fields,omitempty"`
+}
+
+type jsonMapper struct {
+	Input       string `json:"input"`
+	Output      string `json:"output"`
+	Description string `json:"description,omitempty"`
+}
+
+// JSON writes the page as a JSON document
+func JSON(w io.Writer, p *Page) error {
+	d := p.Docs.Details()
+	result := &jsonPage{
+		Plugin:      p.Plugin,
+		Type:        p.Type,
+		Name:        p.Name,
+		Description: d.Description,
+		Example:     d.Example,
+		Input:       d.Input,
+		Output:      d.Output,
+		Fields:      jsonFields(p.Docs.Fields()),
+	}
+	for _, m := range d.Mappers {
+		result.Mappers = append(result.Mappers, jsonMapper(m))
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(result)
+}
+
+func jsonFields(fields []*FieldDocs) []*jsonField {
+	var result []*jsonField
+	for _, f := range fields {
+		result = append(result, &jsonField{
+			Name:     f.Field,
+			Type:     f.Type,
+			Synopsis: f.Synopsis,
+			Summary:  f.Summary,
+			Optional: f.Optional,
+			Default:  f.Default,
+			EnvVar:   f.EnvVar,
+			Block:    f.Block,
+			Fields:   jsonFields(f.Fields),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testNetwork struct {
+	Type string  `hcl:"type,attr"`
+	IP   *string `hcl:"ip,optional"`
+}
+
+type testConfig struct {
+	Name    string       `hcl:"name,attr"`
+	Memory  int          `hcl:"memory,optional"`
+	Network *testNetwork `hcl:"network,block"`
+}
+
+func testPage(t *testing.T) *Page {
+	d, err := New(FromConfig(&testConfig{}))
+	require.NoError(t, err)
+
+	d.Description("Runs machines.\n.Not a request")
+	d.Example("vm {\n  memory = 1024\n}")
+	d.AddMapper("config.Network", "string", "Network | type")
+	require.NoError(t, d.SetField("memory", "Memory in MB", Default("512"), EnvVar("TEST_MEMORY")))
+	require.NoError(t, d.SetField("network.ip", "Address of \\ the machine"))
+
+	return &Page{Plugin: "test", Type: "provider", Name: "test", Docs: d}
+}
+
+func TestFromConfig_nested(t *testing.T) {
+	d, err := New(FromConfig(&testConfig{}))
+	require.NoError(t, err)
+
+	fields := d.Fields()
+	require.Len(t, fields, 3)
+	require.Equal(t, "memory", fields[0].Field)
+	require.True(t, fields[0].Optional)
+	require.Equal(t, "name", fields[1].Field)
+	require.False(t, fields[1].Optional)
+
+	network := fields[2]
+	require.True(t, network.Block)
+	require.True(t, network.Optional)
+	require.Len(t, network.Fields, 2)
+	require.Equal(t, "ip", network.Fields[0].Field)
+	require.NotNil(t, network.SubField("type"))
+}
+
+func TestMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Markdown(&buf, testPage(t)))
+
+	out := buf.String()
+	require.Contains(t, out, "# test provider\n")
+	require.Contains(t, out, "```hcl\nvm {\n  memory = 1024\n}\n```")
+	require.Contains(t, out, "### `memory`\n\n_`int`, optional_\n\nMemory in MB\n")
+	require.Contains(t, out, "- Default: `512`\n- Environment variable: `TEST_MEMORY`\n")
+	require.Contains(t, out, "### `network.ip`\n")
+	require.Contains(t, out, "| `config.Network` | `string` | Network \\| type |")
+}
+
+func TestMan(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Man(&buf, testPage(t)))
+
+	out := buf.String()
+	require.Contains(t, out, ".TH TEST-PROVIDER 7")
+	require.Contains(t, out, "Runs machines.\n\\&.Not a request\n")
+	require.Contains(t, out, ".nf\nvm {\n  memory = 1024\n}\n.fi\n")
+	require.Contains(t, out, ".RS\n.TP\n.B ip\n")
+	require.Contains(t, out, "Address of \\e the machine")
+}
+
+func TestJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, JSON(&buf, testPage(t)))
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	require.Equal(t, "provider", result["type"])
+
+	fields := result["fields"].([]interface{})
+	require.Len(t, fields, 3)
+	network := fields[2].(map[string]interface{})
+	require.Equal(t, "network", network["name"])
+	require.Equal(t, true, network["block"])
+	nested := network["fields"].([]interface{})
+	require.Equal(t, "Address of \\ the machine", nested[0].(map[string]interface{})["synopsis"])
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, Generate(dir, FormatMan, testPage(t)))
+
+	_, err := os.Stat(filepath.Join(dir, "test-provider.7"))
+	require.NoError(t, err)
+
+	require.Error(t, Generate(dir, Format("pdf"), testPage(t)))
+}
@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/docs"
+)
+
+// DocsCommand is the argument which causes Main to render the
+// documentation of the plugin components instead of serving them
+const DocsCommand = "docs"
+
+// DocPages returns the documentation of each component served
+// by the plugin configured with the given options. A page is
+// returned for each component type a component implements,
+// named using the name of the component. Components which are
+// not documented are skipped.
+func DocPages(opts ...Option) ([]*docs.Page, error) {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.Name == "" {
+		ep, err := os.Executable()
+		if err != nil {
+			return nil, err
+		}
+		c.Name = path.Base(ep)
+	}
+
+	return docPages(&c)
+}
+
+func docPages(c *config) ([]*docs.Page, error) {
+	var pages []*docs.Page
+	for _, raw := range c.Components {
+		comp := raw
+		if cwo, ok := raw.(*component.ComponentWithOptions); ok {
+			comp = cwo.Component
+		}
+
+		d, err := component.Documentation(comp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get documentation for %T: %w", comp, err)
+		}
+		if d == nil {
+			continue
+		}
+
+		for _, typ := range componentTypes(comp) {
+			pages = append(pages, &docs.Page{
+				Plugin: c.Name,
+				Type:   typeName(typ),
+				Name:   componentName(comp),
+				Docs:   d,
+			})
+		}
+	}
+
+	return pages, nil
+}
+
+// componentTypes returns the component types implemented by
+// the value, sorted by name
+func componentTypes(c interface{}) []component.Type {
+	var types []component.Type
+	ct := reflect.TypeOf(c)
+	for typ, iface := range component.TypeMap {
+		if ct.Implements(reflect.TypeOf(iface).Elem()) {
+			types = append(types, typ)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].String() < types[j].String()
+	})
+
+	return types
+}
+
+// componentName returns the name of the component. Components
+// may provide their name with a Name method, otherwise the name
+// of the Go type is converted into words, like "ssh communicator"
+// for SSHCommunicator.
+func componentName(c interface{}) string {
+	if n, ok := c.(interface{ Name() string }); ok {
+		return n.Name()
+	}
+
+	t := reflect.TypeOf(c)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return words(t.Name())
+}
+
+// typeName converts the name of the component type into
+// words, like "synced folder"
+func typeName(t component.Type) string {
+	return words(t.String())
+}
+
+// words converts a camel case name into lower case words.
+// Acronyms are kept as a single word.
+func words(name string) string {
+	runes := []rune(name)
+	var sb strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			next := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || next {
+				sb.WriteByte(' ')
+			}
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+
+	return sb.String()
+}
+
+// runDocs renders the component documentation using the flags
+// given after the docs command. Pages are written to the output
+// directory when set, otherwise they are written to stdout.
+func runDocs(c *config, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet(DocsCommand, flag.ContinueOnError)
+	format := fs.String("format", string(docs.FormatMarkdown), "output format: markdown, man, or json")
+	out := fs.String("out", "", "directory to write documentation into")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	pages, err := docPages(c)
+	if err != nil {
+		return err
+	}
+
+	f := docs.Format(*format)
+	if *out != "" {
+		return docs.Generate(*out, f, pages...)
+	}
+	for _, p := range pages {
+		if err := docs.Render(stdout, f, p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/docs"
+)
+
+type testPushConfig struct {
+	Bucket string `hcl:"bucket"`
+}
+
+type testS3Push struct{}
+
+func (p *testS3Push) PushFunc() interface{}        { return nil }
+func (p *testS3Push) Config() (interface{}, error) { return &testPushConfig{}, nil }
+
+type testFTPPush struct{}
+
+func (p *testFTPPush) Name() string                 { return "ftp" }
+func (p *testFTPPush) PushFunc() interface{}        { return nil }
+func (p *testFTPPush) Config() (interface{}, error) { return &testPushConfig{}, nil }
+
+func TestDocPages(t *testing.T) {
+	require := require.New(t)
+
+	pages, err := DocPages(WithName("myplugin"),
+		WithComponents(&testS3Push{}, &testFTPPush{}))
+	require.NoError(err)
+	require.Len(pages, 2)
+	require.Equal("myplugin", pages[0].Plugin)
+	require.Equal("push", pages[0].Type)
+	require.Equal("test s3 push", pages[0].Name)
+	require.Equal("ftp", pages[1].Name)
+
+	// Components of the same type are written to separate pages
+	dir := t.TempDir()
+	require.NoError(docs.Generate(dir, docs.FormatMarkdown, pages...))
+	entries, err := os.ReadDir(dir)
+	require.NoError(err)
+	require.Len(entries, 2)
+	require.FileExists(filepath.Join(dir, "ftp-push.md"))
+	require.FileExists(filepath.Join(dir, "test-s3-push-push.md"))
+}
+
+func TestWords(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("synced folder", words("SyncedFolder"))
+	require.Equal("ssh communicator", words("SSHCommunicator"))
+	require.Equal("winrm", words("winrm"))
+}
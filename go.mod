@@ -15,10 +15,12 @@ require (
 	github.com/hashicorp/go-hclog v0.14.1
 	github.com/hashicorp/go-multierror v1.1.0
 	github.com/hashicorp/go-plugin v1.3.0
-	github.com/hashicorp/hcl/v2 v2.6.0
+	github.com/hashicorp/go-version v1.9.0
+	github.com/hashicorp/hcl/v2 v2.8.2
 	github.com/lab47/vterm v0.0.0-20201001232628-a9dd795f94c2
 	github.com/mattn/go-colorable v0.1.8
 	github.com/mattn/go-isatty v0.0.12
+	github.com/mattn/go-runewidth v0.0.7
 	github.com/mitchellh/go-glint v0.0.0-20200930000256-df5e721f3258
 	github.com/mitchellh/go-testing-interface v1.14.1
 	github.com/mitchellh/mapstructure v1.3.3
@@ -28,6 +30,7 @@ require (
 	github.com/oklog/ulid v1.3.1
 	github.com/olekukonko/tablewriter v0.0.4
 	github.com/stretchr/testify v1.7.5
+	github.com/zclconf/go-cty v1.2.0
 	golang.org/x/crypto v0.1.0
 	golang.org/x/sys v0.5.0
 	golang.org/x/term v0.5.0
@@ -51,7 +54,6 @@ require (
 	github.com/hashicorp/yamux v0.0.0-20180604194846-3520598351bb // indirect
 	github.com/kr/pretty v0.1.0 // indirect
 	github.com/kr/text v0.1.0 // indirect
-	github.com/mattn/go-runewidth v0.0.7 // indirect
 	github.com/mitchellh/go-wordwrap v1.0.1 // indirect
 	github.com/oklog/run v1.0.0 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
@@ -59,7 +61,6 @@ require (
 	github.com/stretchr/objx v0.4.0 // indirect
 	github.com/tj/go-spin v1.1.0 // indirect
 	github.com/y0ssar1an/q v1.0.7 // indirect
-	github.com/zclconf/go-cty v1.2.0 // indirect
 	golang.org/x/net v0.7.0 // indirect
 	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
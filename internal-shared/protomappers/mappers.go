@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/mitchellh/mapstructure"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -28,6 +29,7 @@ import (
 	"github.com/hashicorp/vagrant-plugin-sdk/config"
 	"github.com/hashicorp/vagrant-plugin-sdk/core"
 	"github.com/hashicorp/vagrant-plugin-sdk/datadir"
+	"github.com/hashicorp/vagrant-plugin-sdk/docs"
 	"github.com/hashicorp/vagrant-plugin-sdk/helper/path"
 	"github.com/hashicorp/vagrant-plugin-sdk/helper/types"
 	"github.com/hashicorp/vagrant-plugin-sdk/internal-shared/cacher"
@@ -127,6 +129,10 @@ var All = []interface{}{
 	ConfigDataToFinalize,
 	ConfigData,
 	ConfigDataProto,
+	Provenance,
+	ProvenanceProto,
+	Documentation,
+	DocumentationProto,
 	ConfigMerge,
 	ConfigMergeProto,
 	ConfigFinalize,
@@ -924,6 +930,12 @@ func ConfigData(
 	if input.Source != nil {
 		result.Source = input.Source.Name
 	}
+	if len(input.Provenance) > 0 {
+		result.Provenance = make(map[string]*component.Provenance, len(input.Provenance))
+		for key, p := range input.Provenance {
+			result.Provenance[key] = Provenance(p)
+		}
+	}
 
 	return result, nil
 }
@@ -950,12 +962,151 @@ func ConfigDataProto(
 	if err != nil {
 		return nil, err
 	}
-	return &vagrant_plugin_sdk.Args_ConfigData{
+	result := &vagrant_plugin_sdk.Args_ConfigData{
 		Data: iproto,
 		Source: &vagrant_plugin_sdk.Args_Class{
 			Name: input.Source,
 		},
-	}, nil
+	}
+	if len(input.Provenance) > 0 {
+		result.Provenance = make(map[string]*vagrant_plugin_sdk.Args_Provenance, len(input.Provenance))
+		for key, p := range input.Provenance {
+			result.Provenance[key] = ProvenanceProto(p)
+		}
+	}
+
+	return result, nil
+}
+
+func Provenance(
+	input *vagrant_plugin_sdk.Args_Provenance,
+) *component.Provenance {
+	result := &component.Provenance{
+		Layer: input.Layer,
+		File:  input.File,
+	}
+	if r := input.Range; r != nil {
+		result.Range.Filename = r.Filename
+		if r.Start != nil {
+			result.Range.Start = hcl.Pos{Line: int(r.Start.Line), Column: int(r.Start.Column), Byte: int(r.Start.Byte)}
+		}
+		if r.End != nil {
+			result.Range.End = hcl.Pos{Line: int(r.End.Line), Column: int(r.End.Column), Byte: int(r.End.Byte)}
+		}
+	}
+
+	return result
+}
+
+func ProvenanceProto(
+	input *component.Provenance,
+) *vagrant_plugin_sdk.Args_Provenance {
+	return &vagrant_plugin_sdk.Args_Provenance{
+		Layer: input.Layer,
+		File:  input.File,
+		Range: &vagrant_plugin_sdk.Args_SourceRange{
+			Filename: input.Range.Filename,
+			Start: &vagrant_plugin_sdk.Args_SourcePos{
+				Line:   int64(input.Range.Start.Line),
+				Column: int64(input.Range.Start.Column),
+				Byte:   int64(input.Range.Start.Byte),
+			},
+			End: &vagrant_plugin_sdk.Args_SourcePos{
+				Line:   int64(input.Range.End.Line),
+				Column: int64(input.Range.End.Column),
+				Byte:   int64(input.Range.End.Byte),
+			},
+		},
+	}
+}
+
+func Documentation(
+	input *vagrant_plugin_sdk.Config_Documentation,
+) (*docs.Documentation, error) {
+	d, err := docs.New()
+	if err != nil {
+		return nil, err
+	}
+
+	d.Description(input.Description)
+	d.Example(input.Example)
+	d.Input(input.Input)
+	d.Output(input.Output)
+	for _, f := range input.Fields {
+		if err = d.OverrideField(fieldDocs(f)); err != nil {
+			return nil, err
+		}
+	}
+	for _, m := range input.Mappers {
+		d.AddMapper(m.Input, m.Output, m.Description)
+	}
+
+	return d, nil
+}
+
+func DocumentationProto(
+	input *docs.Documentation,
+) *vagrant_plugin_sdk.Config_Documentation {
+	details := input.Details()
+	result := &vagrant_plugin_sdk.Config_Documentation{
+		Description: details.Description,
+		Example:     details.Example,
+		Input:       details.Input,
+		Output:      details.Output,
+		Fields:      map[string]*vagrant_plugin_sdk.Config_FieldDocumentation{},
+	}
+	for _, f := range input.Fields() {
+		result.Fields[f.Field] = fieldDocsProto(f)
+	}
+	for _, m := range details.Mappers {
+		result.Mappers = append(result.Mappers, &vagrant_plugin_sdk.Config_MapperDocumentation{
+			Input:       m.Input,
+			Output:      m.Output,
+			Description: m.Description,
+		})
+	}
+
+	return result
+}
+
+func fieldDocs(
+	input *vagrant_plugin_sdk.Config_FieldDocumentation,
+) *docs.FieldDocs {
+	result := &docs.FieldDocs{
+		Field:    input.Name,
+		Type:     input.Type,
+		Synopsis: input.Synopsis,
+		Summary:  input.Summary,
+		Optional: input.Optional,
+		Default:  input.Default,
+		EnvVar:   input.EnvVar,
+		Block:    input.Block,
+	}
+	for _, f := range input.Fields {
+		result.Fields = append(result.Fields, fieldDocs(f))
+	}
+
+	return result
+}
+
+func fieldDocsProto(
+	input *docs.FieldDocs,
+) *vagrant_plugin_sdk.Config_FieldDocumentation {
+	result := &vagrant_plugin_sdk.Config_FieldDocumentation{
+		Name:     input.Field,
+		Type:     input.Type,
+		Synopsis: input.Synopsis,
+		Summary:  input.Summary,
+		Optional: input.Optional,
+		Default:  input.Default,
+		EnvVar:   input.EnvVar,
+		Block:    input.Block,
+	}
+	for _, f := range input.Fields {
+		result.Fields = append(result.Fields, fieldDocsProto(f))
+	}
+
+	return result
 }
 
 func ConfigMerge(
@@ -1959,8 +2110,9 @@ func Plugin(
 		return nil, err
 	}
 	result := &core.NamedPlugin{
-		Name: input.Name,
-		Type: t.String(),
+		Name:    input.Name,
+		Type:    t.String(),
+		Version: input.Version,
 	}
 	if input.Options != nil {
 		result.Options, err = component.UnmarshalOptionsProto(t, input.Options)
@@ -1997,8 +2149,9 @@ func PluginProto(
 		return nil, err
 	}
 	result := &vagrant_plugin_sdk.PluginManager_Plugin{
-		Name: input.Name,
-		Type: t.String(),
+		Name:    input.Name,
+		Type:    t.String(),
+		Version: input.Version,
 	}
 
 	if input.Options != nil {
@@ -8,8 +8,11 @@ import (
 
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/docs"
 	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
 )
 
@@ -36,6 +39,45 @@ func TestMappers(t *testing.T) {
 			&vagrant_plugin_sdk.Args_Logger{Name: "foo"},
 			"",
 		},
+
+		{
+			"Provenance",
+			Provenance,
+			[]interface{}{&vagrant_plugin_sdk.Args_Provenance{
+				Layer: "project",
+				File:  "Vagrantfile.hcl",
+				Range: &vagrant_plugin_sdk.Args_SourceRange{
+					Filename: "Vagrantfile.hcl",
+					Start:    &vagrant_plugin_sdk.Args_SourcePos{Line: 2, Column: 3, Byte: 10},
+					End:      &vagrant_plugin_sdk.Args_SourcePos{Line: 2, Column: 20, Byte: 27},
+				},
+			}},
+			&component.Provenance{
+				Layer: "project",
+				File:  "Vagrantfile.hcl",
+				Range: hcl.Range{
+					Filename: "Vagrantfile.hcl",
+					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 10},
+					End:      hcl.Pos{Line: 2, Column: 20, Byte: 27},
+				},
+			},
+			"",
+		},
+
+		{
+			"ProvenanceProto",
+			ProvenanceProto,
+			[]interface{}{&component.Provenance{Layer: "box", File: "Vagrantfile"}},
+			&vagrant_plugin_sdk.Args_Provenance{
+				Layer: "box",
+				File:  "Vagrantfile",
+				Range: &vagrant_plugin_sdk.Args_SourceRange{
+					Start: &vagrant_plugin_sdk.Args_SourcePos{},
+					End:   &vagrant_plugin_sdk.Args_SourcePos{},
+				},
+			},
+			"",
+		},
 	}
 
 	for _, tt := range cases {
@@ -61,3 +103,25 @@ func TestMappers(t *testing.T) {
 		})
 	}
 }
+
+func TestDocumentation(t *testing.T) {
+	require := require.New(t)
+
+	d, err := docs.New()
+	require.NoError(err)
+	d.Description("Manages machines")
+	d.Example("provider {}")
+	d.AddMapper("string", "int", "Parses the value")
+	require.NoError(d.SetField("memory", "Memory in MB", docs.Default("512")))
+	require.NoError(d.SetField("network.type", "Type of network", docs.EnvVar("NETWORK_TYPE")))
+
+	p := DocumentationProto(d)
+	require.Len(p.Fields, 2)
+	require.True(p.Fields["network"].Block)
+	require.Equal("type", p.Fields["network"].Fields[0].Name)
+
+	result, err := Documentation(p)
+	require.NoError(err)
+	require.Equal(d.Details(), result.Details())
+	require.Equal(d.Fields(), result.Fields())
+}
@@ -20,7 +20,9 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
 	"github.com/hashicorp/vagrant-plugin-sdk/core"
+	"github.com/hashicorp/vagrant-plugin-sdk/docs"
 	"github.com/hashicorp/vagrant-plugin-sdk/internal-shared/cacher"
 	"github.com/hashicorp/vagrant-plugin-sdk/internal-shared/cleanup"
 	"github.com/hashicorp/vagrant-plugin-sdk/internal-shared/dynamic"
@@ -53,6 +55,11 @@ type NamedPluginClient interface {
 	PluginName(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*vagrant_plugin_sdk.PluginInfo_Name, error)
 }
 
+// Defines client interface for plugins providing documentation
+type DocumentedClient interface {
+	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*vagrant_plugin_sdk.Config_Documentation, error)
+}
+
 // BasePlugin contains the information which is common among
 // all plugins. It should be embedded in every plugin type.
 type BasePlugin struct {
@@ -289,6 +296,31 @@ func (b *BaseClient) PluginName() (name string, err error) {
 	return "", errors.New("plugin does not support naming")
 }
 
+// Returns the documentation of the plugin component
+func (b *BaseClient) Documentation() (*docs.Documentation, error) {
+	c, ok := b.Client.(DocumentedClient)
+	if !ok {
+		return nil, errors.New("plugin does not support documentation")
+	}
+
+	r, err := c.Documentation(b.Ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := b.Map(r, (**docs.Documentation)(nil), argmapper.Typed(b.Ctx))
+	if err != nil {
+		b.Logger.Error("failed to convert documentation response to proper type",
+			"value", r,
+			"error", err,
+		)
+
+		return nil, err
+	}
+
+	return d.(*docs.Documentation), nil
+}
+
 // Sets the parent component
 func (b *BaseClient) SetParentComponent(c interface{}) {
 	b.parentComponent = c
@@ -651,6 +683,34 @@ func (b *BaseServer) PluginName(
 	return &vagrant_plugin_sdk.PluginInfo_Name{Name: ""}, nil
 }
 
+// Returns the documentation of the implementation. Empty
+// documentation is returned if the implementation is not
+// documented.
+func (b *BaseServer) Documentation(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (*vagrant_plugin_sdk.Config_Documentation, error) {
+	d, err := component.Documentation(b.impl)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return &vagrant_plugin_sdk.Config_Documentation{}, nil
+	}
+
+	r, err := b.Map(d, (**vagrant_plugin_sdk.Config_Documentation)(nil),
+		argmapper.Typed(ctx))
+	if err != nil {
+		b.Logger.Error("failed to convert documentation into proto message",
+			"error", err,
+		)
+
+		return nil, err
+	}
+
+	return r.(*vagrant_plugin_sdk.Config_Documentation), nil
+}
+
 // Generate full mapper list. This will add our locally
 // defined mappers to the given list, ensuring duplicates
 // aren't added.
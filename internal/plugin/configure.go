@@ -12,7 +12,6 @@ package plugin
 // 	"google.golang.org/grpc"
 
 // 	"github.com/hashicorp/vagrant-plugin-sdk/component"
-// 	"github.com/hashicorp/vagrant-plugin-sdk/docs"
 // 	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
 // )
 
@@ -112,90 +111,10 @@ package plugin
 // 	return err
 // }
 
-// // documentation is the shared helper to implement the Documentation RPC call
-// // for components. The logic is the same regardless of component so this can
-// // be called instead.
-// func documentation(impl interface{}) (*vagrant_plugin_sdk.Config_Documentation, error) {
-// 	d, err := component.Documentation(impl)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	dets := d.Details()
-
-// 	v := &vagrant_plugin_sdk.Config_Documentation{
-// 		Description: dets.Description,
-// 		Example:     dets.Example,
-// 		Input:       dets.Input,
-// 		Output:      dets.Output,
-// 		Fields:      make(map[string]*vagrant_plugin_sdk.Config_FieldDocumentation),
-// 	}
-
-// 	for _, f := range d.Fields() {
-// 		v.Fields[f.Field] = &vagrant_plugin_sdk.Config_FieldDocumentation{
-// 			Name:     f.Field,
-// 			Type:     f.Type,
-// 			Default:  f.Default,
-// 			Synopsis: f.Synopsis,
-// 			Summary:  f.Summary,
-// 			EnvVar:   f.EnvVar,
-// 			Optional: f.Optional,
-// 		}
-// 	}
-
-// 	for _, m := range dets.Mappers {
-// 		v.Mappers = append(v.Mappers, &vagrant_plugin_sdk.Config_MapperDocumentation{
-// 			Input:       m.Input,
-// 			Output:      m.Output,
-// 			Description: m.Description,
-// 		})
-// 	}
-
-// 	return v, nil
-// }
-
-// // configStructCall is the shared helper to call the ConfigStruct RPC call
-// // and return the proper struct value for decoding configuration.
-// func documentationCall(ctx context.Context, c configurableClient) (*docs.Documentation, error) {
-// 	resp, err := c.Documentation(ctx, &empty.Empty{})
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	d, err := docs.New()
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	d.Example(resp.Example)
-// 	d.Description(resp.Description)
-// 	d.Input(resp.Input)
-// 	d.Output(resp.Output)
-
-// 	for _, f := range resp.Fields {
-// 		d.OverrideField(&docs.FieldDocs{
-// 			Field:    f.Name,
-// 			Type:     f.Type,
-// 			Default:  f.Default,
-// 			Synopsis: f.Synopsis,
-// 			Summary:  f.Summary,
-// 			Optional: f.Optional,
-// 			EnvVar:   f.EnvVar,
-// 		})
-// 	}
-
-// 	for _, m := range resp.Mappers {
-// 		d.AddMapper(m.Input, m.Output, m.Description)
-// 	}
-
-// 	return d, nil
-// }
-
 // // configurableClient is the interface implemented by all gRPC services that
 // // have the configuration RPC methods. We use this with the helpers above
 // // to extract shared logic for component configuration.
 // type configurableClient interface {
 // 	ConfigStruct(context.Context, *empty.Empty, ...grpc.CallOption) (*vagrant_plugin_sdk.Config_StructResp, error)
 // 	Configure(context.Context, *vagrant_plugin_sdk.Config_ConfigureRequest, ...grpc.CallOption) (*empty.Empty, error)
-// 	Documentation(context.Context, *empty.Empty, ...grpc.CallOption) (*vagrant_plugin_sdk.Config_Documentation, error)
 // }
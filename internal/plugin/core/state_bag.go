@@ -6,12 +6,18 @@ package core
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
+	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-plugin"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/hashicorp/vagrant-plugin-sdk/core"
 	vplugin "github.com/hashicorp/vagrant-plugin-sdk/internal/plugin"
+	"github.com/hashicorp/vagrant-plugin-sdk/multistep"
 	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
 )
 
@@ -54,7 +60,7 @@ func (c *stateBagClient) Get(key string) (value interface{}) {
 		c.Logger.Error("failed to get state bag value", "key", key, "error", err)
 		return
 	}
-	err = json.Unmarshal([]byte(r.Value), value)
+	err = json.Unmarshal([]byte(r.Value), &value)
 	if err != nil {
 		c.Logger.Error("failed to unmarshal state bag value", "key", key,
 			"value", r.Value, "error", err)
@@ -69,7 +75,7 @@ func (c *stateBagClient) GetOk(key string) (value interface{}, ok bool) {
 		c.Logger.Error("failed to get state bag value", "key", key, "error", err)
 		return
 	}
-	err = json.Unmarshal([]byte(r.Value), value)
+	err = json.Unmarshal([]byte(r.Value), &value)
 	if err != nil {
 		c.Logger.Error("failed to unmarshal state bag value", "key", key)
 		return
@@ -78,8 +84,38 @@ func (c *stateBagClient) GetOk(key string) (value interface{}, ok bool) {
 	return
 }
 
+// GetAs implements multistep.DecodingStateBag so typed keys are
+// converted to the requested type using the registered mappers.
+func (c *stateBagClient) GetAs(key string, expectedType interface{}) (interface{}, bool, error) {
+	r, err := c.client.GetOk(c.Ctx, &vagrant_plugin_sdk.StateBag_GetRequest{
+		Key: key})
+	if err != nil {
+		return nil, false, err
+	}
+	if !r.Ok {
+		return nil, false, nil
+	}
+	var value interface{}
+	if err = json.Unmarshal([]byte(r.Value), &value); err != nil {
+		return nil, true, fmt.Errorf("failed to unmarshal value: %w", err)
+	}
+	if value == nil {
+		return nil, true, nil
+	}
+	v, err := c.Map(value, expectedType, argmapper.Typed(c.Ctx))
+	if err != nil {
+		return nil, true, err
+	}
+	return v, true, nil
+}
+
 func (c *stateBagClient) Put(key string, value interface{}) {
 	v, err := json.Marshal(value)
+	if err != nil {
+		c.Logger.Error("failed to marshal state bag value", "key", key,
+			"value", value, "error", err)
+		return
+	}
 	_, err = c.client.Put(c.Ctx, &vagrant_plugin_sdk.StateBag_PutRequest{
 		Key: key, Value: string(v)})
 	if err != nil {
@@ -99,6 +135,17 @@ func (c *stateBagClient) Remove(key string) {
 	return
 }
 
+// Keys implements multistep.ListableStateBag. If the keys cannot
+// be listed an error is logged and no keys are returned.
+func (c *stateBagClient) Keys() []string {
+	r, err := c.client.Keys(c.Ctx, &emptypb.Empty{})
+	if err != nil {
+		c.Logger.Error("failed to list state bag keys", "error", err)
+		return nil
+	}
+	return r.Keys
+}
+
 type stateBagServer struct {
 	*vplugin.BaseServer
 
@@ -110,11 +157,11 @@ func (s *stateBagServer) Get(
 	ctx context.Context,
 	req *vagrant_plugin_sdk.StateBag_GetRequest,
 ) (r *vagrant_plugin_sdk.StateBag_GetResponse, err error) {
-	v := s.Impl.Get(req.Key)
-	if v == nil {
-		v = "null"
+	v, err := s.encode(req.Key, s.Impl.Get(req.Key))
+	if err != nil {
+		return nil, err
 	}
-	r = &vagrant_plugin_sdk.StateBag_GetResponse{Value: v.(string)}
+	r = &vagrant_plugin_sdk.StateBag_GetResponse{Value: v}
 	return
 }
 
@@ -122,14 +169,15 @@ func (s *stateBagServer) GetOk(
 	ctx context.Context,
 	req *vagrant_plugin_sdk.StateBag_GetRequest,
 ) (r *vagrant_plugin_sdk.StateBag_GetOkResponse, err error) {
-	v, ok := s.Impl.GetOk(req.Key)
-	if v == nil {
-		v = "null"
+	raw, ok := s.Impl.GetOk(req.Key)
+	v, err := s.encode(req.Key, raw)
+	if err != nil {
+		return nil, err
 	}
 
 	r = &vagrant_plugin_sdk.StateBag_GetOkResponse{
 		Ok:    ok,
-		Value: v.(string),
+		Value: v,
 	}
 	return
 }
@@ -143,6 +191,26 @@ func (s *stateBagServer) Put(
 	return
 }
 
+// Values received from the client are stored as their JSON
+// encoded string and are returned as is. Any other value stored
+// by the host is encoded before being sent.
+func (s *stateBagServer) encode(key string, v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "null", nil
+	case string:
+		return v, nil
+	}
+
+	result, err := json.Marshal(v)
+	if err != nil {
+		return "", status.Errorf(codes.FailedPrecondition,
+			"state bag value for key %q (%T) cannot be encoded: %s", key, v, err)
+	}
+
+	return string(result), nil
+}
+
 func (s *stateBagServer) Remove(
 	ctx context.Context,
 	req *vagrant_plugin_sdk.StateBag_RemoveRequest,
@@ -152,9 +220,24 @@ func (s *stateBagServer) Remove(
 	return
 }
 
+func (s *stateBagServer) Keys(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (r *vagrant_plugin_sdk.StateBag_KeysResponse, err error) {
+	ls, ok := s.Impl.(multistep.ListableStateBag)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented,
+			"state bag (%T) cannot list keys", s.Impl)
+	}
+	r = &vagrant_plugin_sdk.StateBag_KeysResponse{Keys: ls.Keys()}
+	return
+}
+
 var (
 	_ plugin.Plugin                            = (*StateBagPlugin)(nil)
 	_ plugin.GRPCPlugin                        = (*StateBagPlugin)(nil)
 	_ vagrant_plugin_sdk.StateBagServiceServer = (*stateBagServer)(nil)
 	_ core.StateBag                            = (*stateBagClient)(nil)
+	_ multistep.DecodingStateBag               = (*stateBagClient)(nil)
+	_ multistep.ListableStateBag               = (*stateBagClient)(nil)
 )
@@ -9,6 +9,8 @@ import (
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-plugin"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 
@@ -158,6 +160,23 @@ func (v *vagrantfileClient) GetValue(path ...string) (interface{}, error) {
 	return raw.(*component.Direct).Arguments[0], nil
 }
 
+func (v *vagrantfileClient) GetValueProvenance(path ...string) (*component.Provenance, error) {
+	resp, err := v.client.GetValueProvenance(v.Ctx,
+		&vagrant_plugin_sdk.Vagrantfile_ValueRequest{
+			Path: path,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := v.Map(resp, (**component.Provenance)(nil), argmapper.Typed(v.Ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return raw.(*component.Provenance), nil
+}
+
 // Server
 
 func (v *vagrantfileServer) Target(
@@ -303,8 +322,39 @@ func (v *vagrantfileServer) GetValue(
 	return raw.(*vagrant_plugin_sdk.Args_Direct), nil
 }
 
+func (v *vagrantfileServer) GetValueProvenance(
+	ctx context.Context,
+	req *vagrant_plugin_sdk.Vagrantfile_ValueRequest,
+) (*vagrant_plugin_sdk.Args_Provenance, error) {
+	vp, ok := v.Impl.(core.VagrantfileProvenance)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented,
+			"vagrantfile (%T) does not provide value provenance", v.Impl)
+	}
+
+	p, err := vp.GetValueProvenance(req.Path...)
+	if err != nil {
+		v.Logger.Error("failed to get config value provenance from implementation",
+			"path", req.Path,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	raw, err := v.Map(p, (**vagrant_plugin_sdk.Args_Provenance)(nil), argmapper.Typed(ctx))
+	if err != nil {
+		v.Logger.Error("failed to map config value provenance",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return raw.(*vagrant_plugin_sdk.Args_Provenance), nil
+}
+
 var (
-	_ plugin.Plugin     = (*VagrantfilePlugin)(nil)
-	_ plugin.GRPCPlugin = (*VagrantfilePlugin)(nil)
-	_ core.Vagrantfile  = (*vagrantfileClient)(nil)
+	_ plugin.Plugin              = (*VagrantfilePlugin)(nil)
+	_ plugin.GRPCPlugin          = (*VagrantfilePlugin)(nil)
+	_ core.Vagrantfile           = (*vagrantfileClient)(nil)
+	_ core.VagrantfileProvenance = (*vagrantfileClient)(nil)
 )
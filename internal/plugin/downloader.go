@@ -28,7 +28,7 @@ type DownloaderPlugin struct {
 func (p *DownloaderPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
 	vagrant_plugin_sdk.RegisterDownloaderServiceServer(s, &downloaderServer{
 		Impl:       p.Impl,
-		BaseServer: p.NewServer(broker, nil),
+		BaseServer: p.NewServer(broker, p.Impl),
 	})
 	return nil
 }
@@ -59,10 +59,6 @@ type downloaderClient struct {
 // 	return configureCall(c.Ctx, c.client, v)
 // }
 
-// func (c *downloaderClient) Documentation() (*docs.Documentation, error) {
-// 	return documentationCall(c.Ctx, c.client)
-// }
-
 func (c *downloaderClient) DownloadFunc() interface{} {
 	spec, err := c.client.DownloadSpec(c.Ctx, &emptypb.Empty{})
 	if err != nil {
@@ -107,13 +103,6 @@ type downloaderServer struct {
 // 	return configure(s.Impl, req)
 // }
 
-// func (s *downloaderServer) Documentation(
-// 	ctx context.Context,
-// 	empty *emptypb.Empty,
-// ) (*vagrant_plugin_sdk.Config_Documentation, error) {
-// 	return documentation(s.Impl)
-// }
-
 func (s *downloaderServer) DownloadSpec(
 	ctx context.Context,
 	args *emptypb.Empty,
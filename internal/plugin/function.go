@@ -0,0 +1,266 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+)
+
+// functionProvider provides a fixed set of functions
+type functionProvider struct {
+	funcs map[string]function.Function
+}
+
+func (p *functionProvider) Functions() (map[string]function.Function, error) {
+	return p.funcs, nil
+}
+
+// FunctionPlugin is the plugin used to serve the functions a plugin
+// makes available within HCL Vagrantfiles. The functions are called
+// over gRPC when the host evaluates a Vagrantfile.
+type FunctionPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+
+	Impl component.FunctionProvider // Impl is the concrete implementation
+	*BasePlugin
+}
+
+func (p *FunctionPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	vagrant_plugin_sdk.RegisterFunctionServiceServer(s, &functionServer{
+		Impl:       p.Impl,
+		BaseServer: p.NewServer(broker, nil),
+	})
+	return nil
+}
+
+func (p *FunctionPlugin) GRPCClient(
+	ctx context.Context,
+	broker *plugin.GRPCBroker,
+	c *grpc.ClientConn,
+) (interface{}, error) {
+	return &functionClient{
+		client:     vagrant_plugin_sdk.NewFunctionServiceClient(c),
+		BaseClient: p.NewClient(ctx, broker, nil),
+	}, nil
+}
+
+// functionClient is an implementation of component.FunctionProvider
+// over gRPC. Each function returned calls the plugin when called.
+type functionClient struct {
+	*BaseClient
+
+	client vagrant_plugin_sdk.FunctionServiceClient
+}
+
+func (c *functionClient) Functions() (map[string]function.Function, error) {
+	resp, err := c.client.Spec(c.Ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]function.Function{}
+	for _, s := range resp.Functions {
+		spec := &function.Spec{}
+		for _, p := range s.Params {
+			param, err := functionParam(p)
+			if err != nil {
+				return nil, err
+			}
+			spec.Params = append(spec.Params, *param)
+		}
+		if s.VarParam != nil {
+			if spec.VarParam, err = functionParam(s.VarParam); err != nil {
+				return nil, err
+			}
+		}
+
+		ret, err := ctyjson.UnmarshalType(s.ReturnType)
+		if err != nil {
+			return nil, err
+		}
+		spec.Type = function.StaticReturnType(ret)
+		spec.Impl = c.impl(s.Name)
+
+		result[s.Name] = function.New(spec)
+	}
+
+	return result, nil
+}
+
+// impl returns the implementation of the named function which
+// calls the function within the plugin
+func (c *functionClient) impl(name string) function.ImplFunc {
+	return func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		req := &vagrant_plugin_sdk.Function_CallRequest{Name: name}
+		for _, arg := range args {
+			raw, err := ctyjson.Marshal(arg, cty.DynamicPseudoType)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			req.Args = append(req.Args, raw)
+		}
+
+		resp, err := c.client.Call(c.Ctx, req)
+		if err != nil {
+			// Only the message is useful to the user
+			return cty.NilVal, errors.New(status.Convert(err).Message())
+		}
+
+		return ctyjson.Unmarshal(resp.Result, cty.DynamicPseudoType)
+	}
+}
+
+type functionServer struct {
+	*BaseServer
+
+	Impl component.FunctionProvider
+	vagrant_plugin_sdk.UnimplementedFunctionServiceServer
+}
+
+func (s *functionServer) Spec(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (*vagrant_plugin_sdk.Function_SpecList, error) {
+	if err := isImplemented(s.Impl, "functions"); err != nil {
+		return nil, err
+	}
+
+	funcs, err := s.Impl.Functions()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(funcs))
+	for name := range funcs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := &vagrant_plugin_sdk.Function_SpecList{}
+	for _, name := range names {
+		fn := funcs[name]
+		spec := &vagrant_plugin_sdk.Function_Spec{Name: name}
+
+		// The return type is only known ahead of time when it does
+		// not depend on the values of the arguments
+		var argTypes []cty.Type
+		for _, p := range fn.Params() {
+			param, err := functionParamProto(&p)
+			if err != nil {
+				return nil, err
+			}
+			spec.Params = append(spec.Params, param)
+			argTypes = append(argTypes, p.Type)
+		}
+		if p := fn.VarParam(); p != nil {
+			if spec.VarParam, err = functionParamProto(p); err != nil {
+				return nil, err
+			}
+		}
+
+		ret, err := fn.ReturnType(argTypes)
+		if err != nil {
+			ret = cty.DynamicPseudoType
+		}
+		if spec.ReturnType, err = ctyjson.MarshalType(ret); err != nil {
+			return nil, err
+		}
+
+		result.Functions = append(result.Functions, spec)
+	}
+
+	return result, nil
+}
+
+func (s *functionServer) Call(
+	ctx context.Context,
+	req *vagrant_plugin_sdk.Function_CallRequest,
+) (*vagrant_plugin_sdk.Function_CallResponse, error) {
+	if err := isImplemented(s.Impl, "functions"); err != nil {
+		return nil, err
+	}
+
+	funcs, err := s.Impl.Functions()
+	if err != nil {
+		return nil, err
+	}
+
+	fn, ok := funcs[req.Name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound,
+			"function %q is not provided by this plugin", req.Name)
+	}
+
+	args := make([]cty.Value, len(req.Args))
+	for i, raw := range req.Args {
+		if args[i], err = ctyjson.Unmarshal(raw, cty.DynamicPseudoType); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"invalid argument %d: %s", i+1, err)
+		}
+	}
+
+	val, err := fn.Call(args)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	raw, err := ctyjson.Marshal(val, cty.DynamicPseudoType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vagrant_plugin_sdk.Function_CallResponse{Result: raw}, nil
+}
+
+func functionParam(p *vagrant_plugin_sdk.Function_Parameter) (*function.Parameter, error) {
+	typ, err := ctyjson.UnmarshalType(p.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	return &function.Parameter{
+		Name:             p.Name,
+		Type:             typ,
+		AllowNull:        p.AllowNull,
+		AllowUnknown:     p.AllowUnknown,
+		AllowDynamicType: p.AllowDynamicType,
+	}, nil
+}
+
+func functionParamProto(p *function.Parameter) (*vagrant_plugin_sdk.Function_Parameter, error) {
+	typ, err := ctyjson.MarshalType(p.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vagrant_plugin_sdk.Function_Parameter{
+		Name:             p.Name,
+		Type:             typ,
+		AllowNull:        p.AllowNull,
+		AllowUnknown:     p.AllowUnknown,
+		AllowDynamicType: p.AllowDynamicType,
+	}, nil
+}
+
+var (
+	_ plugin.Plugin                            = (*FunctionPlugin)(nil)
+	_ plugin.GRPCPlugin                        = (*FunctionPlugin)(nil)
+	_ vagrant_plugin_sdk.FunctionServiceServer = (*functionServer)(nil)
+	_ component.FunctionProvider               = (*functionClient)(nil)
+	_ component.FunctionProvider               = (*functionProvider)(nil)
+)
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+)
+
+func TestFunctionPlugin(t *testing.T) {
+	require := require.New(t)
+
+	plugins := Plugins(WithFunctions(map[string]function.Function{
+		"upper":  stdlib.UpperFunc,
+		"concat": stdlib.ConcatFunc,
+		"fail": function.New(&function.Spec{
+			Params: []function.Parameter{{Name: "value", Type: cty.Number}},
+			Type:   function.StaticReturnType(cty.String),
+			Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+				return cty.NilVal, function.NewArgErrorf(0, "invalid value")
+			},
+		}),
+	}))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("function")
+	require.NoError(err)
+	funcs, err := raw.(component.FunctionProvider).Functions()
+	require.NoError(err)
+	require.Len(funcs, 3)
+
+	ctx := &hcl.EvalContext{Functions: funcs}
+	eval := func(src string) (cty.Value, hcl.Diagnostics) {
+		expr, diags := hclsyntax.ParseExpression([]byte(src), "test.hcl", hcl.InitialPos)
+		require.False(diags.HasErrors())
+		return expr.Value(ctx)
+	}
+
+	val, diags := eval(`upper("vagrant")`)
+	require.False(diags.HasErrors())
+	require.Equal(cty.StringVal("VAGRANT"), val)
+
+	val, diags = eval(`concat(["a"], ["b", "c"])`)
+	require.False(diags.HasErrors())
+	require.Equal(3, val.LengthInt())
+
+	_, diags = eval(`fail(1)`)
+	require.True(diags.HasErrors())
+	require.Contains(diags.Error(), "invalid value")
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	"github.com/zclconf/go-cty/cty/function"
 
 	"github.com/hashicorp/vagrant-plugin-sdk/component"
 	"github.com/hashicorp/vagrant-plugin-sdk/internal-shared/cacher"
@@ -57,6 +58,7 @@ func Plugins(opts ...Option) map[int]plugin.PluginSet {
 			"communicator": &CommunicatorPlugin{BasePlugin: bp.Clone()},
 			"config":       &ConfigPlugin{BasePlugin: bp.Clone()},
 			"downloader":   &DownloaderPlugin{BasePlugin: bp.Clone()},
+			"function":     &FunctionPlugin{Impl: &functionProvider{funcs: c.Functions}, BasePlugin: bp.Clone()},
 			"guest":        &GuestPlugin{BasePlugin: bp.Clone()},
 			"host":         &HostPlugin{BasePlugin: bp.Clone()},
 			"mapper":       &MapperPlugin{BasePlugin: bp.Clone()},
@@ -107,6 +109,7 @@ type pluginConfig struct {
 	Name       string
 	Components []interface{}
 	Mappers    []*argmapper.Func
+	Functions  map[string]function.Function
 	Logger     hclog.Logger
 }
 
@@ -127,6 +130,19 @@ func WithMappers(ms ...*argmapper.Func) Option {
 	}
 }
 
+// WithFunctions sets the functions served for use within HCL
+// Vagrantfiles. This will add to the existing functions.
+func WithFunctions(fns map[string]function.Function) Option {
+	return func(c *pluginConfig) {
+		if c.Functions == nil {
+			c.Functions = map[string]function.Function{}
+		}
+		for name, fn := range fns {
+			c.Functions[name] = fn
+		}
+	}
+}
+
 // WithLogger sets the logger for the plugins.
 func WithLogger(log hclog.Logger) Option {
 	return func(c *pluginConfig) { c.Logger = log }
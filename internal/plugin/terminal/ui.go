@@ -10,13 +10,16 @@ import (
 	"net"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	statuspkg "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/hashicorp/vagrant-plugin-sdk/internal/pkg/pty"
@@ -24,6 +27,10 @@ import (
 	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
 )
 
+// progressInterval is the minimum time between progress
+// updates sent to the host
+const progressInterval = 100 * time.Millisecond
+
 // UIPlugin implements plugin.Plugin (specifically GRPCPlugin) for
 // the terminal.UI interface.
 type UIPlugin struct {
@@ -68,6 +75,16 @@ func (p *UIPlugin) GRPCClient(
 		return nil, err
 	}
 
+	// Register the values the host redacts so output from
+	// the plugin is redacted as well
+	sensitiveResp, err := client.Sensitive(ctx, &emptypb.Empty{})
+	if err != nil && statuspkg.Code(err) != codes.Unimplemented {
+		return nil, err
+	}
+	if err == nil {
+		terminal.AddSensitive(sensitiveResp.Values...)
+	}
+
 	evstream, err := client.Events(ctx)
 	if err != nil {
 		return nil, err
@@ -148,6 +165,15 @@ func (s *uiServer) IsMachineReadable(
 	}, nil
 }
 
+func (s *uiServer) Sensitive(
+	ctx context.Context,
+	req *emptypb.Empty,
+) (*vagrant_plugin_sdk.TerminalUI_SensitiveResponse, error) {
+	return &vagrant_plugin_sdk.TerminalUI_SensitiveResponse{
+		Values: terminal.SensitiveValues(),
+	}, nil
+}
+
 func (s *uiServer) Events(stream vagrant_plugin_sdk.TerminalUIService_EventsServer) error {
 	type stepData struct {
 		terminal.Step
@@ -155,14 +181,24 @@ func (s *uiServer) Events(stream vagrant_plugin_sdk.TerminalUIService_EventsServ
 	}
 
 	var (
-		status terminal.Status
-		stdout io.Writer
-		stderr io.Writer
+		status   terminal.Status
+		progress terminal.Progress
+		last     *vagrant_plugin_sdk.TerminalUI_Event_Progress
+		stdout   io.Writer
+		stderr   io.Writer
 
 		sg    terminal.StepGroup
 		steps = map[int32]*stepData{}
 	)
 
+	// Progress is closed and reset when the plugin closes it, so
+	// only the current progress needs to be closed
+	defer func() {
+		if progress != nil {
+			progress.Close()
+		}
+	}()
+
 	for {
 		ev, err := stream.Recv()
 		if err != nil {
@@ -180,12 +216,14 @@ func (s *uiServer) Events(stream vagrant_plugin_sdk.TerminalUIService_EventsServ
 					ev.Line.Msg,
 					terminal.WithStyle(ev.Line.Style),
 					terminal.WithoutNewLine(),
-					terminal.WithColor(ev.Line.Color))
+					terminal.WithColor(ev.Line.Color),
+					terminal.WithTarget(ev.Line.Target))
 			} else {
 				s.Impl.Output(
 					ev.Line.Msg,
 					terminal.WithStyle(ev.Line.Style),
-					terminal.WithColor(ev.Line.Color))
+					terminal.WithColor(ev.Line.Color),
+					terminal.WithTarget(ev.Line.Target))
 			}
 			stream.Send(&vagrant_plugin_sdk.TerminalUI_Response{
 				Event: &vagrant_plugin_sdk.TerminalUI_Response_Input{
@@ -213,7 +251,7 @@ func (s *uiServer) Events(stream vagrant_plugin_sdk.TerminalUIService_EventsServ
 				})
 			}
 
-			s.Impl.NamedValues(values)
+			s.Impl.NamedValues(values, terminal.WithTarget(ev.NamedValues.Target))
 		case *vagrant_plugin_sdk.TerminalUI_Event_Status_:
 			if ev.Status.Msg == "" && !ev.Status.Step {
 				if status != nil {
@@ -231,6 +269,24 @@ func (s *uiServer) Events(stream vagrant_plugin_sdk.TerminalUIService_EventsServ
 					status.Update(ev.Status.Msg)
 				}
 			}
+		case *vagrant_plugin_sdk.TerminalUI_Event_Progress_:
+			if progress == nil {
+				progress = s.Impl.Progress(ev.Progress.Msg, ev.Progress.Total)
+			} else {
+				if ev.Progress.Msg != last.Msg {
+					progress.Update(ev.Progress.Msg)
+				}
+				if ev.Progress.Total != last.Total {
+					progress.SetTotal(ev.Progress.Total)
+				}
+			}
+			progress.SetCurrent(ev.Progress.Current)
+			last = ev.Progress
+
+			if ev.Progress.Close {
+				progress.Close()
+				progress = nil
+			}
 		case *vagrant_plugin_sdk.TerminalUI_Event_Raw_:
 			if stdout == nil {
 				stdout, stderr, err = s.Impl.OutputWriters()
@@ -246,6 +302,14 @@ func (s *uiServer) Events(stream vagrant_plugin_sdk.TerminalUIService_EventsServ
 			}
 		case *vagrant_plugin_sdk.TerminalUI_Event_Table_:
 			tbl := terminal.NewTable(ev.Table.Headers...)
+			tbl.Width = int(ev.Table.Width)
+			for _, c := range ev.Table.Columns {
+				tbl.Columns = append(tbl.Columns, terminal.TableColumn{
+					MinWidth: int(c.MinWidth),
+					MaxWidth: int(c.MaxWidth),
+					Overflow: terminal.Overflow(c.Overflow),
+				})
+			}
 
 			for _, row := range ev.Table.Rows {
 				var trow []terminal.TableEntry
@@ -259,7 +323,7 @@ func (s *uiServer) Events(stream vagrant_plugin_sdk.TerminalUIService_EventsServ
 				tbl.Rows = append(tbl.Rows, trow)
 			}
 
-			s.Impl.Table(tbl)
+			s.Impl.Table(tbl, terminal.WithTarget(ev.Table.Target))
 		case *vagrant_plugin_sdk.TerminalUI_Event_StepGroup_:
 			if sg != nil {
 				sg.Wait()
@@ -305,12 +369,19 @@ func (s *uiServer) Events(stream vagrant_plugin_sdk.TerminalUIService_EventsServ
 				step.Done()
 			}
 		case *vagrant_plugin_sdk.TerminalUI_Event_Input_:
-			result, err := s.Impl.Input(&terminal.Input{
-				Prompt: ev.Input.Prompt,
-				Style:  ev.Input.Style,
-				Secret: ev.Input.Secret,
-				Color:  ev.Input.Color,
-			})
+			input := &terminal.Input{
+				Prompt:  ev.Input.Prompt,
+				Style:   ev.Input.Style,
+				Secret:  ev.Input.Secret,
+				Color:   ev.Input.Color,
+				Choices: ev.Input.Choices,
+				Confirm: ev.Input.Confirm,
+				Default: ev.Input.Default,
+			}
+			if ev.Input.Timeout != nil {
+				input.Timeout = ev.Input.Timeout.AsDuration()
+			}
+			result, err := s.Impl.Input(input)
 
 			var sterr *spb.Status
 			if err != nil {
@@ -366,22 +437,53 @@ func (u *uiBridge) Close() error {
 	return err
 }
 
+// Input implements terminal.UI. The input is displayed by the host
+// while validation is performed here, so the host is asked again
+// when the answer is not valid.
 func (u *uiBridge) Input(input *terminal.Input) (string, error) {
 	if !u.interactive {
 		return "", terminal.ErrNonInteractive
 	}
 
+	for {
+		answer, err := u.input(input)
+		if err != nil {
+			return "", err
+		}
+
+		if input.Validate == nil {
+			return answer, nil
+		}
+		if err := input.Validate(answer); err != nil {
+			u.Output(err.Error(), terminal.WithErrorStyle())
+			continue
+		}
+
+		return answer, nil
+	}
+}
+
+// input sends the input to the host and waits for the answer
+func (u *uiBridge) input(input *terminal.Input) (string, error) {
 	u.evcRecvLock.Lock()
 	defer u.evcRecvLock.Unlock()
 
+	ev := &vagrant_plugin_sdk.TerminalUI_Event_Input{
+		Prompt:  terminal.Redact(input.Prompt),
+		Style:   input.Style,
+		Secret:  input.Secret,
+		Color:   input.Color,
+		Choices: input.Choices,
+		Confirm: input.Confirm,
+		Default: input.Default,
+	}
+	if input.Timeout > 0 {
+		ev.Timeout = durationpb.New(input.Timeout)
+	}
+
 	err := u.evc.Send(&vagrant_plugin_sdk.TerminalUI_Event{
 		Event: &vagrant_plugin_sdk.TerminalUI_Event_Input_{
-			Input: &vagrant_plugin_sdk.TerminalUI_Event_Input{
-				Prompt: input.Prompt,
-				Style:  input.Style,
-				Secret: input.Secret,
-				Color:  input.Color,
-			},
+			Input: ev,
 		},
 	})
 	if err != nil {
@@ -443,6 +545,7 @@ func (u *uiBridge) Output(msg string, raw ...interface{}) {
 				Style:          style,
 				DisableNewLine: disableNewline,
 				Color:          color,
+				Target:         terminal.OptionsTarget(raw...),
 			},
 		},
 	}
@@ -460,13 +563,13 @@ func (u *uiBridge) Output(msg string, raw ...interface{}) {
 
 // Output data as a table of data. Each entry is a row which will be output
 // with the columns lined up nicely.
-func (u *uiBridge) NamedValues(tvalues []terminal.NamedValue, _ ...terminal.Option) {
+func (u *uiBridge) NamedValues(tvalues []terminal.NamedValue, opts ...terminal.Option) {
 	var values []*vagrant_plugin_sdk.TerminalUI_Event_NamedValue
 
 	for _, nv := range tvalues {
 		values = append(values, &vagrant_plugin_sdk.TerminalUI_Event_NamedValue{
 			Name:  nv.Name,
-			Value: fmt.Sprintf("%s", nv.Value),
+			Value: terminal.Redact(fmt.Sprintf("%s", nv.Value)),
 		})
 	}
 
@@ -481,6 +584,7 @@ func (u *uiBridge) NamedValues(tvalues []terminal.NamedValue, _ ...terminal.Opti
 		Event: &vagrant_plugin_sdk.TerminalUI_Event_NamedValues_{
 			NamedValues: &vagrant_plugin_sdk.TerminalUI_Event_NamedValues{
 				Values: values,
+				Target: optionsTarget(opts),
 			},
 		},
 	})
@@ -521,8 +625,10 @@ func (u *uiBridge) OutputWriters() (stdout io.Writer, stderr io.Writer, err erro
 			ew.Close()
 		}()
 
-		u.stdout = dw
-		u.stderr = ew
+		// Sensitive values are removed before the data
+		// is sent to the remote side
+		u.stdout = terminal.RedactWriter(dw)
+		u.stderr = terminal.RedactWriter(ew)
 	})
 
 	return u.stdout, u.stderr, nil
@@ -562,24 +668,34 @@ func (u *uiBridge) sendData(r io.ReadCloser, stderr bool) {
 }
 
 func (u *uiBridge) Table(tbl *terminal.Table, opts ...terminal.Option) {
-	var (
-		ptbl *vagrant_plugin_sdk.TerminalUI_Event_Table
-		rows []*vagrant_plugin_sdk.TerminalUI_Event_TableRow
-	)
+	ptbl := &vagrant_plugin_sdk.TerminalUI_Event_Table{
+		Target: optionsTarget(opts),
+		Width:  int32(tbl.Width),
+	}
 
-	ptbl.Headers = tbl.Headers
+	for _, c := range tbl.Columns {
+		ptbl.Columns = append(ptbl.Columns, &vagrant_plugin_sdk.TerminalUI_Event_TableColumn{
+			MinWidth: int32(c.MinWidth),
+			MaxWidth: int32(c.MaxWidth),
+			Overflow: int32(c.Overflow),
+		})
+	}
+
+	for _, h := range tbl.Headers {
+		ptbl.Headers = append(ptbl.Headers, terminal.Redact(h))
+	}
 
 	for _, row := range tbl.Rows {
 		var entries []*vagrant_plugin_sdk.TerminalUI_Event_TableEntry
 
 		for _, ent := range row {
 			entries = append(entries, &vagrant_plugin_sdk.TerminalUI_Event_TableEntry{
-				Value: ent.Value,
+				Value: terminal.Redact(ent.Value),
 				Color: ent.Color,
 			})
 		}
 
-		rows = append(rows, &vagrant_plugin_sdk.TerminalUI_Event_TableRow{
+		ptbl.Rows = append(ptbl.Rows, &vagrant_plugin_sdk.TerminalUI_Event_TableRow{
 			Entries: entries,
 		})
 	}
@@ -598,6 +714,16 @@ func (u *uiBridge) Table(tbl *terminal.Table, opts ...terminal.Option) {
 	})
 }
 
+// optionsTarget returns the target set by the options, if any
+func optionsTarget(opts []terminal.Option) string {
+	raw := make([]interface{}, len(opts))
+	for i, opt := range opts {
+		raw[i] = opt
+	}
+
+	return terminal.OptionsTarget(raw...)
+}
+
 type uiBridgeSGStep struct {
 	sg   *uiBridgeSG
 	id   int32
@@ -623,7 +749,7 @@ func (u *uiBridgeSGStep) TermOutput() io.Writer {
 			console.Close()
 		}()
 
-		u.stdout = dw
+		u.stdout = terminal.RedactWriter(dw)
 	})
 
 	return u.stdout
@@ -663,7 +789,7 @@ func (u *uiBridgeSGStep) sendData(r io.ReadCloser, stderr bool) {
 }
 
 func (u *uiBridgeSGStep) Update(str string, args ...interface{}) {
-	msg := fmt.Sprintf(str, args...)
+	msg := terminal.Redact(fmt.Sprintf(str, args...))
 
 	u.sg.ui.mu.Lock()
 	defer u.sg.ui.mu.Unlock()
@@ -757,7 +883,7 @@ type uiBridgeSG struct {
 
 // Start a step in the output
 func (u *uiBridgeSG) Add(str string, args ...interface{}) terminal.Step {
-	msg := fmt.Sprintf(str, args...)
+	msg := terminal.Redact(fmt.Sprintf(str, args...))
 
 	u.ui.mu.Lock()
 	defer u.ui.mu.Unlock()
@@ -837,7 +963,7 @@ func (u *uiBridgeStatus) Update(msg string) {
 	u.b.evc.Send(&vagrant_plugin_sdk.TerminalUI_Event{
 		Event: &vagrant_plugin_sdk.TerminalUI_Event_Status_{
 			Status: &vagrant_plugin_sdk.TerminalUI_Event_Status{
-				Msg: msg,
+				Msg: terminal.Redact(msg),
 			},
 		},
 	})
@@ -859,7 +985,7 @@ func (u *uiBridgeStatus) Step(status string, msg string) {
 		Event: &vagrant_plugin_sdk.TerminalUI_Event_Status_{
 			Status: &vagrant_plugin_sdk.TerminalUI_Event_Status{
 				Status: status,
-				Msg:    msg,
+				Msg:    terminal.Redact(msg),
 				Step:   true,
 			},
 		},
@@ -885,6 +1011,30 @@ func (u *uiBridgeStatus) Close() error {
 	return nil
 }
 
+// Progress returns a live-updating progress of an operation measured
+// in bytes. Updates are sent to the host at most once per interval.
+func (u *uiBridge) Progress(msg string, total int64) terminal.Progress {
+	return terminal.NewProgress(msg, total, progressInterval, func(s terminal.ProgressStats, final bool) {
+		u.mu.Lock()
+		defer u.mu.Unlock()
+
+		if u.evc == nil {
+			return
+		}
+
+		u.evc.Send(&vagrant_plugin_sdk.TerminalUI_Event{
+			Event: &vagrant_plugin_sdk.TerminalUI_Event_Progress_{
+				Progress: &vagrant_plugin_sdk.TerminalUI_Event_Progress{
+					Msg:     s.Message,
+					Total:   s.Total,
+					Current: s.Current,
+					Close:   final,
+				},
+			},
+		})
+	})
+}
+
 var (
 	_ plugin.Plugin                              = (*UIPlugin)(nil)
 	_ plugin.GRPCPlugin                          = (*UIPlugin)(nil)
@@ -1,6 +1,6 @@
 // Code generated by go-bindata. DO NOT EDIT.
 // sources:
-// localizer/locales/en.json (1.473kB)
+// localizer/locales/en.json (2.200kB)
 
 package localizer
 
@@ -68,7 +68,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _localizerLocalesEnJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x54\xc1\x8a\xe4\x46\x0c\xbd\xcf\x57\x88\xbe\xf4\x65\x70\xb3\xd7\xb9\x65\x09\x0b\x03\xd9\xb0\x0c\xb3\x0b\x81\x06\x23\x57\xc9\xed\x4a\xec\x92\x53\x92\xdb\xdd\x0c\xfd\xef\x41\x55\xb6\x67\xd3\xd9\x1c\xf6\xd6\xad\x92\x9e\xde\x7b\x92\xfc\xf6\x00\xb0\x43\xef\x43\x3c\xd5\xe7\x0f\x75\xc3\x97\xdd\x13\xec\x8e\xf1\x97\x1c\x82\x6f\x1f\xe0\x1b\x9e\x12\x46\x85\x86\x2f\x8f\xb0\x7f\x7b\xab\x3e\xf2\xe5\x77\x1c\xe8\x76\xdb\x57\xdb\xe3\x1c\xfa\x1e\x70\x52\x1e\x50\x83\xc3\xbe\xbf\xc2\x34\x9e\x12\x7a\x02\xed\x82\x58\x71\x05\x5f\x7a\x42\x21\x70\x1c\x25\x78\x4a\x4b\x86\xb5\xd1\x8e\x40\x78\x4a\x8e\x4a\x66\xa7\x3a\xca\xd3\xe1\x30\xcf\x73\x75\x2e\x2d\xa6\xb1\x72\x3c\x1c\x3c\x3b\x39\x34\x7c\x21\x39\xb4\x9c\x06\xd4\x63\xdc\x3d\x9a\x86\x86\x2f\x35\x7a\x6f\xec\x17\xee\xa6\x65\x7b\xf2\x4c\x52\x47\xd6\xba\xc3\x33\xd5\x03\x29\x7a\x54\xac\xff\x14\x8e\x75\x1b\x7a\xb2\xba\xd7\x8e\x60\xbf\x3e\x55\xf6\xb4\x07\x7b\x83\x96\x53\xa6\xd8\xf0\xe5\xce\x01\x98\x51\x20\xb2\x42\xcb\x53\xf4\x15\x7c\x34\x66\x90\xe8\xef\x29\xa4\x45\x7a\x46\x08\x11\x38\x99\x66\x83\x5a\x4d\x53\x06\x4f\x4a\x69\x08\x91\x32\xfe\x98\xf8\x9c\x9d\x09\x9a\x81\x07\xf3\xaf\xe5\x54\xc1\x73\x0b\x57\x9e\x4a\x60\x61\xf2\x08\x63\xf1\x13\xbd\x07\xfc\x31\x71\x65\x08\x9a\xab\x85\x07\xe2\x48\x40\xbd\xd0\x8f\x61\x22\x6b\x68\xaf\x9b\x4e\x97\x08\x35\xeb\x46\xdd\x82\x41\xc0\x71\x4a\xd3\xa8\xd5\x31\x1e\xe3\xaf\xec\xa6\x81\xa2\xa2\x06\x8e\x59\x9a\x25\xad\x96\x0d\xa8\xe0\x30\x42\x43\xc5\x1d\x58\x80\xbe\xbe\xfc\xf6\xf4\x33\x13\xae\x3a\x1d\xfa\xf7\x49\x6e\xb3\x1b\x82\x88\xad\xed\xe2\xb6\xaf\xdb\x40\xbd\x97\x75\x92\x6b\x1e\xa0\x08\xbb\x80\x4a\x1e\xe6\xa0\xdd\xff\x4d\x12\xc7\x91\x30\x89\x79\xd6\x10\x2c\xe0\x39\x79\x6d\x00\xb9\x41\xae\x7b\x59\x42\x9f\x2c\x92\x2f\x61\x59\x6e\x8a\x32\xa5\xff\xae\x51\x87\x02\xd8\xf7\x77\x50\x92\x5d\x7c\xf9\x77\xec\x09\xee\xf1\xe5\x76\x2b\xf2\x3d\x8d\x89\x9c\x29\xa9\xdb\x1e\x4f\xab\xd2\x65\x6d\x3c\x58\x30\xd3\xfb\xd4\xe3\xc9\x34\x05\x81\xf7\x9a\x0a\x9e\x23\xb4\x93\x1a\xbf\x44\x99\xae\x00\xb7\xdb\x3a\x86\xe5\x8c\x6d\x9d\x1b\x02\x3c\x63\xe8\xb1\xe9\xa9\x2a\xcd\x29\x25\x4e\x75\xe4\x18\xa2\x52\x42\xa7\xe1\x4c\xf5\x14\x8c\xc4\x86\x20\x80\xaa\x34\x8c\x9a\xad\x63\xc8\xa9\x2d\x3a\x7a\x77\xfe\xeb\xb3\x1d\x03\xc2\x8c\xd7\xb2\x5b\x8b\x25\x02\x08\xaf\xaf\x7f\x54\xf0\x99\x45\xc1\xe0\x39\x8a\xa5\x6e\xd7\xf2\x5d\x72\xc9\x05\xbb\x65\xfb\x9c\xb4\xe1\x34\xa5\xb2\x84\x32\x07\x75\x1d\xe5\x31\xfa\x20\xc6\xbf\x9c\xe1\x52\x69\xeb\xba\x0d\xcb\x73\x41\xe5\x04\x69\x8a\xdf\x7d\xcc\xb4\xcb\x5c\x8a\xf0\xf5\x2a\xf3\xe7\x63\xca\x90\x77\xce\xa7\x6c\xfa\x97\xe5\x8f\x19\x9f\x61\xed\x84\xad\x2d\x89\xed\x9e\xed\x15\xba\xbf\xb2\x09\x03\xba\xce\x8e\xde\xca\x3e\x97\xdf\xcb\xb8\x12\x8d\x9c\x8a\x7d\x06\x11\xcc\xd4\xb8\x57\x28\x7d\x81\x63\x51\x23\x57\x51\x1a\x76\x0f\xb7\x87\x7f\x02\x00\x00\xff\xff\x8e\x83\x51\xc2\xc1\x05\x00\x00")
+var _localizerLocalesEnJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x95\x55\x4d\x6f\x1b\x39\x0c\xbd\xe7\x57\x10\xbe\xf8\x12\x8c\xd1\xab\x6f\xdb\xee\x16\x28\xb0\x2d\x82\x34\x0d\xb0\x40\x80\x81\x3c\xe2\xd8\xda\xd5\x48\xb3\xfa\xb0\x6b\x04\xfe\xef\x4b\x52\x9a\x71\xec\x64\x5b\xf4\x36\x96\x28\xf2\xbd\xc7\x47\xfa\xf9\x06\x60\xa1\xb4\x36\x6e\xdb\xee\xdf\xb5\x1b\xff\x7d\xb1\x86\xc5\x93\xfb\x4d\x8e\xe0\xf1\x1d\x3c\xaa\x6d\x50\x2e\x01\x5d\xdd\xc2\xf2\xf9\xb9\x79\xef\xbf\x7f\x51\x03\x9e\x4e\xcb\x66\xbe\x3c\x18\x6b\x41\xe5\xe4\x07\x95\x4c\xa7\xac\x3d\x42\x1e\xe9\x4a\x23\xa4\x9d\x89\xfc\xb8\x81\x3b\x8b\x2a\x22\x74\xde\x45\xa3\x31\xd4\x08\x2e\x93\x76\x08\xd1\xe7\xd0\x61\x89\xdc\xa5\x34\xc6\xf5\x6a\x75\x38\x1c\x9a\x7d\x29\x91\xc7\xa6\xf3\xc3\x4a\xfb\x2e\xae\x28\x06\xe3\xaa\xf7\x81\xaa\x3d\xb9\xc5\x2d\x73\xa0\xb3\x96\x78\x30\xfa\x8a\x9d\xb9\xcc\x57\xda\x63\x6c\x9d\x4f\xed\x4e\xed\xb1\x1d\x30\x29\xad\x92\x6a\xff\x8e\xde\xb5\xbd\xb1\xc8\xef\x1e\x08\xc5\x72\xba\x6a\xf8\x6a\x09\x7c\x07\x54\x49\x20\x52\xa2\x2b\x05\xe0\xa0\x22\x50\x5a\x0a\xc9\x4e\x37\xf0\x9e\x91\x41\xc0\x7f\xb3\x09\x95\xba\x64\x30\x0e\x7c\x60\xce\x9c\x6a\x12\x2d\x79\xd0\x98\x30\x0c\xc6\xa1\xe4\x1f\x83\xdf\x8b\x32\x26\x49\xe2\x81\xf5\xa3\x17\x0d\x7c\xea\xe1\xe8\x73\x39\xa8\x48\x6e\x61\x2c\x7a\x12\x6b\x50\x6f\x03\xa7\x0a\x26\xc9\xeb\xe8\x07\xf4\x54\x06\x2d\xbd\x78\x33\x0d\xb1\x30\xfd\x71\xe6\xd9\x05\x54\x49\x78\xab\x34\x1f\x12\x9d\xce\x87\x90\xc7\xd4\x3c\xb9\x27\xf7\xbb\xef\xf2\x80\x2e\x51\xcf\xbd\x13\x6a\x1c\x34\x49\x46\xcd\x81\x4e\x39\xd8\x60\x51\x07\x6a\xa2\x6f\xf7\x7f\xae\x7f\xa5\xc3\xcd\x2e\x0d\xf6\xdc\xc9\xb9\x77\x83\x89\x91\x6d\x5b\xd5\xd6\xd4\x47\xb4\x3a\x4e\x9d\x9c\xe2\x40\xc5\xe8\x3b\xa3\x12\x6a\xb2\x69\xda\xfd\x5f\x27\xd5\x38\xa2\x0a\x91\x35\x23\xc4\x35\xb9\x04\x4f\x05\x40\x0a\xc8\xbb\xfb\x7a\xf4\x91\x4f\x64\x12\xaa\xb9\xd1\xc5\x1c\x5e\xdb\x68\x47\xdd\xa4\xa9\xb8\x4a\x15\x45\xc5\xfb\xcb\xb3\x35\x5c\xe7\x8f\xa7\x53\xa1\xaf\x71\x0c\xd8\x31\x93\xb6\xb7\x6a\x3b\x31\xad\xb6\xa1\x04\x74\x28\xf0\x3e\xd2\x07\x73\xa2\x7e\x9d\xdf\x90\x0f\xa8\x49\x39\x31\xbe\x80\x02\x37\x82\xef\x67\x3b\x9a\x3a\xc6\x6c\x67\x52\x40\xed\x95\xb1\x6a\x63\xb1\x29\xc5\x31\x04\x1f\x68\x84\x9c\x71\xe4\x59\xd5\x25\x43\x83\x94\x0d\x83\x98\x33\x10\xc9\x94\x70\x18\x93\x48\x47\xe6\xe3\xd0\x5e\xd1\x58\xcf\xca\x7f\xfb\xc4\xc3\xa0\xc8\xdf\xc7\xe2\xad\x2a\x09\x3d\x85\x87\x87\xbf\x1a\xf8\xec\x63\x02\x4e\x4f\x6b\x82\x43\xe7\x69\x79\x11\x5c\x62\x81\x67\x99\xd7\x49\x6f\xb6\x39\x14\x13\x46\x2a\xd4\xed\x50\xda\xa8\x4d\x64\xfc\x65\x0c\xeb\x4b\xb6\xeb\xdc\x2c\xed\x4b\x56\x32\x6e\xc8\xee\xc5\x32\x23\xac\x8c\xa5\x10\x1f\x6d\xde\x1a\xd7\x1a\x17\x13\xb5\x90\x14\x68\xcb\xaa\x9a\xe5\x97\x7b\xd1\x7d\xf2\x52\xe7\x33\x19\xa5\x0a\x59\x1f\x72\x03\xbe\xf0\x08\x92\xe4\x26\xc5\xba\xef\xa2\x88\x06\xd6\xd3\xd2\x24\xc0\xd4\x2a\x1a\xba\x23\x93\x4a\xca\xb8\xc9\x81\xb5\xc4\xc6\x38\x45\x97\x62\x5c\xf9\x64\xe7\xb1\x85\xbe\x96\x5c\x62\x9d\xfa\x3d\x79\x66\x46\xbf\x57\xd6\xe8\x76\x8f\x21\x92\x4e\x2d\x2f\xe1\x14\xa8\x44\x9a\x68\xd4\x9b\x97\x42\x49\xa5\x0f\x73\x24\x33\x9b\x56\xe1\x1b\xa4\x0d\xb7\x4b\xaa\x08\x8e\x3f\xd8\x2f\x57\x28\xea\x54\xfd\x40\xb9\x73\xa7\x34\x6c\x8e\xa5\x75\xb5\x2f\xb2\x57\x36\x59\x6c\xc6\xd2\x9e\x75\xbd\xa8\x31\x31\x34\x8e\xb6\xc9\x48\xae\xd8\x58\xfc\x71\xc1\x39\x11\x6f\xa8\x49\x06\x8e\x79\x2c\xdf\x1c\xc6\x75\x5f\x81\x99\xad\xfb\x4a\xa7\x0b\x48\xb1\xcd\x8e\xa2\xbc\xdd\xa3\xbe\x44\xf2\x13\xb2\x17\x36\x9a\x32\xcc\xee\xad\xb0\xd9\xbd\x79\xa4\x5d\x53\x56\x7a\xef\xad\xf5\x07\x36\x4e\x2d\xb1\x66\x83\x10\xbe\xbb\xe0\x49\x89\xe1\x6c\x8c\xfa\x67\x23\xff\x8a\x59\x26\xe5\x6a\xa1\x04\xe1\x75\x57\x7f\xb0\x0a\x32\x2d\xfc\xcf\xc4\xb0\x31\xf2\x4a\xe5\x75\xa9\xba\x7f\xa4\xf6\xa0\xba\x1d\xff\x97\xf1\xb3\xcf\xe5\x7b\xee\xe9\xe8\x43\xd9\x0a\x9c\xc2\x70\x13\xdd\x32\x41\xa9\x0b\xa4\xb7\x90\x8f\x47\xca\x39\x2c\x6e\x4e\x37\xff\x01\x61\x7e\x9f\xcb\x98\x08\x00\x00")
 
 func localizerLocalesEnJsonBytes() ([]byte, error) {
 	return bindataRead(
@@ -83,8 +83,8 @@ func localizerLocalesEnJson() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "localizer/locales/en.json", size: 1473, mode: os.FileMode(0664), modTime: time.Unix(1690839147, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x72, 0x2, 0x64, 0x7e, 0xc, 0x29, 0x5b, 0xc1, 0xd5, 0x40, 0xd1, 0x69, 0x9d, 0xb8, 0x84, 0xed, 0xce, 0x37, 0x1, 0x95, 0xf8, 0x25, 0xa2, 0x2a, 0x8, 0x1b, 0xa6, 0x14, 0xa3, 0xde, 0xef, 0xef}}
+	info := bindataFileInfo{name: "localizer/locales/en.json", size: 2200, mode: os.FileMode(0664), modTime: time.Unix(1792379783, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x22, 0x68, 0xda, 0xbf, 0xb, 0x23, 0x7a, 0xe1, 0x2c, 0x8d, 0xe7, 0x42, 0x25, 0x4e, 0xe2, 0x70, 0x26, 0x49, 0xea, 0x32, 0x46, 0x58, 0x6d, 0x48, 0xbb, 0x78, 0x3b, 0xa, 0x43, 0xb7, 0x2e, 0x36}}
 	return a, nil
 }
 
@@ -12,12 +12,15 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
 	"github.com/mattn/go-colorable"
+	"github.com/zclconf/go-cty/cty/function"
 
 	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	vconfig "github.com/hashicorp/vagrant-plugin-sdk/config"
 	"github.com/hashicorp/vagrant-plugin-sdk/internal-shared/dynamic"
 	"github.com/hashicorp/vagrant-plugin-sdk/internal-shared/protomappers"
 	sdkplugin "github.com/hashicorp/vagrant-plugin-sdk/internal/plugin"
 	"github.com/hashicorp/vagrant-plugin-sdk/internal/stdio"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
 )
 
 // Proto generation
@@ -58,8 +61,9 @@ func Main(opts ...Option) {
 	// We have to rewrite the fatih/color package output/error writers
 	// to be our plugin stdout/stderr. We use the color package a lot in
 	// our UI and this causes the UI to work.
-	color.Output = colorable.NewColorable(stdio.Stdout())
-	color.Error = colorable.NewColorable(stdio.Stderr())
+	// Sensitive values are redacted from anything written through them.
+	color.Output = terminal.RedactWriter(colorable.NewColorable(stdio.Stdout()))
+	color.Error = terminal.RedactWriter(colorable.NewColorable(stdio.Stderr()))
 
 	// Create our logger. We also set this as the default logger in case
 	// any other libraries are using hclog and our plugin doesn't properly
@@ -69,7 +73,7 @@ func Main(opts ...Option) {
 		log = hclog.New(&hclog.LoggerOptions{
 			Name:   "plugin",
 			Level:  hclog.Trace,
-			Output: os.Stderr,
+			Output: terminal.RedactWriter(os.Stderr),
 			Color:  hclog.AutoColor,
 
 			// Critical that this is JSON-formatted. Since we're a plugin this
@@ -92,6 +96,26 @@ func Main(opts ...Option) {
 		c.Name = path.Base(ep)
 	}
 
+	// Render the component documentation when requested instead
+	// of serving the plugin
+	if len(os.Args) > 1 && os.Args[1] == DocsCommand {
+		if err := runDocs(&c, os.Args[2:], os.Stdout); err != nil {
+			log.Error("failed to render documentation", "error", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Functions for use in Vagrantfiles are served to the host and
+	// cannot replace any of the functions provided by the SDK
+	reserved := vconfig.Functions("")
+	for name := range c.Functions {
+		if _, ok := reserved[name]; ok {
+			log.Error("cannot serve function, name is reserved", "function", name)
+			os.Exit(1)
+		}
+	}
+
 	// Build up our mappers
 	var mappers []*argmapper.Func
 	for _, raw := range c.Mappers {
@@ -115,6 +139,7 @@ func Main(opts ...Option) {
 		VersionedPlugins: sdkplugin.Plugins(
 			sdkplugin.WithComponents(c.Components...),
 			sdkplugin.WithMappers(mappers...),
+			sdkplugin.WithFunctions(c.Functions),
 			sdkplugin.WithLogger(log),
 			sdkplugin.WithName(c.Name),
 		),
@@ -133,6 +158,9 @@ type config struct {
 	// Mappers is the list of mapper functions.
 	Mappers []interface{}
 
+	// Functions are made available within HCL Vagrantfiles.
+	Functions map[string]function.Function
+
 	InProcess *plugin.ServeTestConfig
 
 	Log hclog.Logger
@@ -190,3 +218,18 @@ func WithComponent(comp interface{}, options interface{}) Option {
 func WithMappers(ms ...interface{}) Option {
 	return func(c *config) { c.Mappers = append(c.Mappers, ms...) }
 }
+
+// WithFunctions specifies functions which will be available within
+// HCL Vagrantfiles. The functions are served to the host, which calls
+// them over gRPC when evaluating a Vagrantfile. Function names cannot
+// replace any of the functions provided by the SDK.
+func WithFunctions(fns map[string]function.Function) Option {
+	return func(c *config) {
+		if c.Functions == nil {
+			c.Functions = map[string]function.Function{}
+		}
+		for name, fn := range fns {
+			c.Functions[name] = fn
+		}
+	}
+}
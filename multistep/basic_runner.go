@@ -7,6 +7,7 @@ import (
 	"context"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 type runState int32
@@ -23,6 +24,12 @@ type BasicRunner struct {
 	// modified.
 	Steps []Step
 
+	// CleanupGracePeriod is the amount of time steps implementing
+	// StepCleanupWithContext are given to clean up after the context
+	// passed to Run has been cancelled. If unset, the
+	// DefaultCleanupGracePeriod is used.
+	CleanupGracePeriod time.Duration
+
 	l     sync.Mutex
 	state runState
 }
@@ -55,6 +62,12 @@ func (b *BasicRunner) Run(ctx context.Context, state StateBag) {
 		}
 	}()
 
+	// Steps which have been run and must be cleaned up
+	var ran []Step
+	defer func() {
+		cleanupSteps(ctx, b.CleanupGracePeriod, state, ran)
+	}()
+
 	for _, step := range b.Steps {
 		if step == nil {
 			continue
@@ -71,7 +84,7 @@ func (b *BasicRunner) Run(ctx context.Context, state StateBag) {
 		}
 
 		action := step.Run(ctx, state)
-		defer step.Cleanup(state)
+		ran = append(ran, step)
 
 		if _, ok := state.GetOk(StateCancelled); ok {
 			break
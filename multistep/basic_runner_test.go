@@ -5,8 +5,13 @@ package multistep
 
 import (
 	"context"
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
 )
 
 func TestBasicRunner_ImplRunner(t *testing.T) {
@@ -176,3 +181,147 @@ func TestBasicRunner_Cancel_Special(t *testing.T) {
 		t.Errorf("cancelled should be in state bag")
 	}
 }
+
+type TestStepCleanupCtx struct {
+	TestStepFn
+	cleanupCtx func(context.Context, StateBag) error
+}
+
+func (s TestStepCleanupCtx) CleanupWithContext(ctx context.Context, state StateBag) error {
+	return s.cleanupCtx(ctx, state)
+}
+
+func TestBasicRunner_CleanupWithContext(t *testing.T) {
+	data := new(BasicStateBag)
+	noop := func(context.Context, StateBag) StepAction { return ActionContinue }
+
+	r := &BasicRunner{Steps: []Step{
+		TestStepCleanupCtx{
+			TestStepFn: TestStepFn{run: noop},
+			cleanupCtx: func(context.Context, StateBag) error {
+				return errors.New("first")
+			},
+		},
+		&TestStepAcc{Data: "a"},
+		TestStepCleanupCtx{
+			TestStepFn: TestStepFn{
+				run: noop,
+				cleanup: func(StateBag) {
+					t.Fatal("Cleanup should not be called")
+				},
+			},
+			cleanupCtx: func(context.Context, StateBag) error {
+				return errors.New("second")
+			},
+		},
+	}}
+	r.Run(context.Background(), data)
+
+	// Steps without context cleanup are still cleaned up
+	expected := []string{"a"}
+	results := data.Get("cleanup").([]string)
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("unexpected result: %#v", results)
+	}
+
+	err := CleanupErrors(data)
+	merr, ok := err.(*multierror.Error)
+	if !ok {
+		t.Fatalf("expected multierror, got: %#v", err)
+	}
+	if len(merr.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got: %d", len(merr.Errors))
+	}
+	// Cleanup is run in reverse order
+	if !strings.Contains(merr.Errors[0].Error(), "second") {
+		t.Errorf("unexpected first error: %s", merr.Errors[0])
+	}
+}
+
+func TestBasicRunner_CleanupPanic(t *testing.T) {
+	data := new(BasicStateBag)
+	noop := func(context.Context, StateBag) StepAction { return ActionContinue }
+
+	r := &BasicRunner{Steps: []Step{
+		&TestStepAcc{Data: "a"},
+		TestStepFn{
+			run: noop,
+			cleanup: func(StateBag) {
+				panic("cleanup failure")
+			},
+		},
+		TestStepCleanupCtx{
+			TestStepFn: TestStepFn{run: noop},
+			cleanupCtx: func(context.Context, StateBag) error {
+				panic("context cleanup failure")
+			},
+		},
+	}}
+	r.Run(context.Background(), data)
+
+	// Steps after a panic are still cleaned up
+	expected := []string{"a"}
+	results := data.Get("cleanup").([]string)
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("unexpected result: %#v", results)
+	}
+
+	merr, ok := CleanupErrors(data).(*multierror.Error)
+	if !ok {
+		t.Fatalf("expected multierror, got: %#v", CleanupErrors(data))
+	}
+	if len(merr.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got: %d", len(merr.Errors))
+	}
+	if !strings.Contains(merr.Errors[0].Error(), "context cleanup failure") {
+		t.Errorf("unexpected first error: %s", merr.Errors[0])
+	}
+	if !strings.Contains(merr.Errors[1].Error(), "cleanup failure") {
+		t.Errorf("unexpected second error: %s", merr.Errors[1])
+	}
+}
+
+func TestBasicRunner_CleanupWithContext_grace(t *testing.T) {
+	topCtx, topCtxCancel := context.WithCancel(context.Background())
+	data := new(BasicStateBag)
+
+	var cleanupErr error
+	r := &BasicRunner{
+		CleanupGracePeriod: 10 * time.Millisecond,
+		Steps: []Step{
+			TestStepCleanupCtx{
+				TestStepFn: TestStepFn{
+					run: func(context.Context, StateBag) StepAction {
+						topCtxCancel()
+						return ActionContinue
+					},
+				},
+				cleanupCtx: func(ctx context.Context, _ StateBag) error {
+					// The cleanup context must still be valid after
+					// the runner context is cancelled
+					if err := ctx.Err(); err != nil {
+						cleanupErr = err
+						return err
+					}
+
+					select {
+					case <-ctx.Done():
+						return ctx.Err()
+					case <-time.After(time.Second):
+						cleanupErr = errors.New("cleanup context was not cancelled")
+						return cleanupErr
+					}
+				},
+			},
+		},
+	}
+	r.Run(topCtx, data)
+
+	if cleanupErr != nil {
+		t.Fatalf("err: %s", cleanupErr)
+	}
+
+	if !errors.Is(CleanupErrors(data), context.Canceled) {
+		t.Fatalf("expected cancellation error, got: %s", CleanupErrors(data))
+	}
+}
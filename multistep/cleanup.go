@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package multistep
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// This is the key set in the state bag when one or more steps returned
+// an error from CleanupWithContext or panicked during cleanup. The value
+// is a *multierror.Error.
+const StateCleanupErrors = "cleanup_errors"
+
+// DefaultCleanupGracePeriod is the amount of time cleanup is allowed to
+// continue after the runner's context has been cancelled when a runner
+// does not define its own grace period.
+const DefaultCleanupGracePeriod = time.Minute
+
+// StepCleanupWithContext can be optionally implemented by a Step. When
+// implemented, runners will call CleanupWithContext instead of Cleanup.
+type StepCleanupWithContext interface {
+	// CleanupWithContext is called in place of Step.Cleanup. The
+	// provided context is not the context passed to Run. It remains
+	// valid after the runner's context has been cancelled, and is
+	// cancelled once the runner's cleanup grace period has elapsed.
+	//
+	// Any error returned is collected and stored in the state bag
+	// under the StateCleanupErrors key.
+	CleanupWithContext(context.Context, StateBag) error
+}
+
+// CleanupErrors returns the errors collected from step cleanups
+// stored in the state bag. If no errors were encountered, nil is
+// returned.
+func CleanupErrors(state StateBag) error {
+	raw, ok := state.GetOk(StateCleanupErrors)
+	if !ok {
+		return nil
+	}
+
+	if err, ok := raw.(*multierror.Error); ok && err != nil {
+		return err.ErrorOrNil()
+	}
+
+	return nil
+}
+
+// cleanupSteps runs the cleanup of the given steps in reverse order
+// and stores any errors encountered in the state bag.
+func cleanupSteps(
+	ctx context.Context, // context provided to the runner
+	grace time.Duration, // time allowed after ctx is cancelled
+	state StateBag,
+	steps []Step,
+) {
+	cleanupCtx, cancel := cleanupContext(ctx, grace)
+	defer cancel()
+
+	var result *multierror.Error
+	for i := len(steps) - 1; i >= 0; i-- {
+		if err := cleanupStep(cleanupCtx, steps[i], state); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	if result == nil {
+		return
+	}
+
+	// Nested runners may share a state bag, so make sure we
+	// don't lose any errors already stored
+	if existing, ok := state.Get(StateCleanupErrors).(*multierror.Error); ok && existing != nil {
+		result = multierror.Append(existing, result.Errors...)
+	}
+
+	state.Put(StateCleanupErrors, result)
+}
+
+// cleanupStep runs the cleanup of a single step. A panic during the
+// cleanup is recovered and returned as an error so the cleanup of the
+// remaining steps is not skipped.
+func cleanupStep(ctx context.Context, step Step, state StateBag) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cleanup of step %s panicked: %v", stepName(step), r)
+		}
+	}()
+
+	s, ok := step.(StepCleanupWithContext)
+	if !ok {
+		step.Cleanup(state)
+		return nil
+	}
+
+	if err := s.CleanupWithContext(ctx, state); err != nil {
+		return fmt.Errorf("cleanup of step %s failed: %w", stepName(step), err)
+	}
+
+	return nil
+}
+
+// cleanupContext returns a context which is not cancelled when the
+// parent context is cancelled. Instead, it will be cancelled after
+// the grace period has elapsed from the parent cancellation.
+func cleanupContext(
+	parent context.Context,
+	grace time.Duration,
+) (context.Context, context.CancelFunc) {
+	if grace <= 0 {
+		grace = DefaultCleanupGracePeriod
+	}
+
+	ctx, cancel := context.WithCancel(detachedContext{parent})
+
+	go func() {
+		select {
+		case <-parent.Done():
+		case <-ctx.Done():
+			return
+		}
+
+		t := time.NewTimer(grace)
+		defer t.Stop()
+
+		select {
+		case <-t.C:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// detachedContext provides the values of the parent context
+// without any of the cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"time"
 )
 
 // DebugLocation is the location where the pause is occurring when debugging
@@ -33,6 +34,25 @@ type StepWrapper interface {
 // inspect the state of the multi-step sequence at a given step.
 type DebugPauseFn func(DebugLocation, string, StateBag)
 
+// DebugAction is the action the DebugRunner should take after
+// a pause.
+type DebugAction uint
+
+const (
+	// Continue running the steps
+	DebugActionContinue DebugAction = iota
+	// After a run, skip the next step. Before a cleanup, skip
+	// the cleanup of the named step.
+	DebugActionSkip
+	// After a run, halt the sequence. Before a cleanup, stop
+	// pausing for any remaining cleanups.
+	DebugActionAbort
+)
+
+// DebugPauseActionFn is the type signature for a pause function which
+// controls how the DebugRunner proceeds.
+type DebugPauseActionFn func(DebugLocation, string, StateBag) DebugAction
+
 // DebugRunner is a Runner that runs the given set of steps in order,
 // but pauses between each step until it is told to continue.
 type DebugRunner struct {
@@ -44,6 +64,15 @@ type DebugRunner struct {
 	// The function is given the state so that the state can be inspected.
 	PauseFn DebugPauseFn
 
+	// PauseActionFn is like PauseFn but the returned action determines
+	// how the debug runner proceeds. If set, PauseFn is ignored.
+	PauseActionFn DebugPauseActionFn
+
+	// CleanupGracePeriod is the amount of time steps are given to
+	// clean up after the context passed to Run has been cancelled.
+	// See BasicRunner.CleanupGracePeriod.
+	CleanupGracePeriod time.Duration
+
 	l      sync.Mutex
 	runner *BasicRunner
 }
@@ -56,37 +85,52 @@ func (r *DebugRunner) Run(ctx context.Context, state StateBag) {
 	r.runner = new(BasicRunner)
 	r.l.Unlock()
 
-	pauseFn := r.PauseFn
+	pauseFn := r.PauseActionFn
 
-	// If no PauseFn is specified, use the default
+	// If no PauseActionFn is specified, use the PauseFn or the default
 	if pauseFn == nil {
-		pauseFn = DebugPauseDefault
+		fn := r.PauseFn
+		if fn == nil {
+			fn = DebugPauseDefault
+		}
+
+		pauseFn = func(loc DebugLocation, name string, state StateBag) DebugAction {
+			fn(loc, name, state)
+			return DebugActionContinue
+		}
 	}
 
 	// Rebuild the steps so that we insert the pause step after each
+	d := &debugState{}
 	steps := make([]Step, len(r.Steps)*2)
 	for i, step := range r.Steps {
 		if step == nil {
 			continue
 		}
-		steps[i*2] = step
-		name := ""
-		if wrapped, ok := step.(StepWrapper); ok {
-			name = wrapped.InnerStepName()
-		} else {
-			name = reflect.Indirect(reflect.ValueOf(step)).Type().Name()
-		}
+		s := &debugStep{Step: step, debug: d}
+		steps[i*2] = s
 		steps[(i*2)+1] = &debugStepPause{
-			name,
-			pauseFn,
+			StepName: stepName(step),
+			PauseFn:  pauseFn,
+			step:     s,
 		}
 	}
 
 	// Then just use a basic runner to run it
 	r.runner.Steps = steps
+	r.runner.CleanupGracePeriod = r.CleanupGracePeriod
 	r.runner.Run(ctx, state)
 }
 
+// stepName returns the human readable name of the step.
+func stepName(step Step) string {
+	if wrapped, ok := step.(StepWrapper); ok {
+		return wrapped.InnerStepName()
+	}
+
+	return reflect.Indirect(reflect.ValueOf(step)).Type().Name()
+}
+
 // DebugPauseDefault is the default pause function when using the
 // DebugRunner if no PauseFn is specified. It outputs some information
 // to stderr about the step and waits for keyboard input on stdin before
@@ -106,16 +150,101 @@ func DebugPauseDefault(loc DebugLocation, name string, state StateBag) {
 	fmt.Scanln(&line)
 }
 
+// debugState is shared by the steps of a DebugRunner
+type debugState struct {
+	mu           sync.Mutex
+	skipNext     bool
+	noMorePauses bool
+}
+
+// debugStep wraps a step so it can be skipped
+type debugStep struct {
+	Step
+
+	debug       *debugState
+	skipped     bool
+	skipCleanup bool
+}
+
+func (s *debugStep) Run(ctx context.Context, state StateBag) StepAction {
+	s.debug.mu.Lock()
+	skip := s.debug.skipNext
+	s.debug.skipNext = false
+	s.debug.mu.Unlock()
+
+	if skip {
+		s.skipped = true
+		return ActionContinue
+	}
+
+	return s.Step.Run(ctx, state)
+}
+
+func (s *debugStep) Cleanup(state StateBag) {
+	if s.skipped || s.skipCleanup {
+		return
+	}
+
+	s.Step.Cleanup(state)
+}
+
+func (s *debugStep) CleanupWithContext(ctx context.Context, state StateBag) error {
+	if s.skipped || s.skipCleanup {
+		return nil
+	}
+
+	if c, ok := s.Step.(StepCleanupWithContext); ok {
+		return c.CleanupWithContext(ctx, state)
+	}
+
+	s.Step.Cleanup(state)
+	return nil
+}
+
+func (s *debugStep) InnerStepName() string {
+	return stepName(s.Step)
+}
+
 type debugStepPause struct {
 	StepName string
-	PauseFn  DebugPauseFn
+	PauseFn  DebugPauseActionFn
+
+	step *debugStep
 }
 
 func (s *debugStepPause) Run(ctx context.Context, state StateBag) StepAction {
-	s.PauseFn(DebugLocationAfterRun, s.StepName, state)
+	if s.step.skipped {
+		return ActionContinue
+	}
+
+	switch s.PauseFn(DebugLocationAfterRun, s.StepName, state) {
+	case DebugActionSkip:
+		s.step.debug.mu.Lock()
+		s.step.debug.skipNext = true
+		s.step.debug.mu.Unlock()
+	case DebugActionAbort:
+		return ActionHalt
+	}
+
 	return ActionContinue
 }
 
 func (s *debugStepPause) Cleanup(state StateBag) {
-	s.PauseFn(DebugLocationBeforeCleanup, s.StepName, state)
+	d := s.step.debug
+	d.mu.Lock()
+	noMorePauses := d.noMorePauses
+	d.mu.Unlock()
+
+	if s.step.skipped || noMorePauses {
+		return
+	}
+
+	switch s.PauseFn(DebugLocationBeforeCleanup, s.StepName, state) {
+	case DebugActionSkip:
+		s.step.skipCleanup = true
+	case DebugActionAbort:
+		d.mu.Lock()
+		d.noMorePauses = true
+		d.mu.Unlock()
+	}
 }
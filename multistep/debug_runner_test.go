@@ -5,8 +5,10 @@ package multistep
 
 import (
 	"context"
+	"errors"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -201,3 +203,118 @@ func TestDebugPauseDefault(t *testing.T) {
 		t.Fatal("didn't complete")
 	}
 }
+
+func TestDebugRunner_PauseAction(t *testing.T) {
+	data := new(BasicStateBag)
+	steps := []Step{
+		&TestStepAcc{Data: "a"},
+		&TestStepAcc{Data: "b"},
+		&TestStepAcc{Data: "c"},
+		&TestStepAcc{Data: "d"},
+	}
+
+	pauses := 0
+	pauseFn := func(loc DebugLocation, name string, state StateBag) DebugAction {
+		pauses++
+		switch pauses {
+		case 1:
+			// After "a", skip "b"
+			return DebugActionSkip
+		case 2:
+			// After "c", halt
+			return DebugActionAbort
+		case 3:
+			// Before cleanup of "c", skip it
+			return DebugActionSkip
+		}
+		return DebugActionContinue
+	}
+
+	r := &DebugRunner{Steps: steps, PauseActionFn: pauseFn}
+	r.Run(context.Background(), data)
+
+	expected := []string{"a", "c"}
+	results := data.Get("data").([]string)
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("unexpected results: %#v", results)
+	}
+
+	expected = []string{"a"}
+	results = data.Get("cleanup").([]string)
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("unexpected results: %#v", results)
+	}
+
+	if _, ok := data.GetOk(StateHalted); !ok {
+		t.Errorf("halted should be in state bag")
+	}
+
+	if pauses != 4 {
+		t.Errorf("unexpected number of pauses: %d", pauses)
+	}
+}
+
+type testDebugUI struct {
+	inputs []string
+	output []string
+	tables [][][]string
+}
+
+func (u *testDebugUI) Interactive() bool { return true }
+
+func (u *testDebugUI) Ask(string) (string, error) {
+	if len(u.inputs) == 0 {
+		return "", errors.New("no input")
+	}
+	v := u.inputs[0]
+	u.inputs = u.inputs[1:]
+	return v, nil
+}
+
+func (u *testDebugUI) Message(_ DebugMessageLevel, msg string) {
+	u.output = append(u.output, msg)
+}
+
+func (u *testDebugUI) Table(_ []string, rows [][]string) {
+	u.tables = append(u.tables, rows)
+}
+
+func TestDebugPauseUI(t *testing.T) {
+	ui := &testDebugUI{inputs: []string{"i", "bogus", "s"}}
+	state := new(BasicStateBag)
+	state.Put("name", "value")
+
+	action := DebugPauseUI(ui)(DebugLocationAfterRun, "stepA", state)
+	if action != DebugActionSkip {
+		t.Fatalf("unexpected action: %d", action)
+	}
+
+	if len(ui.tables) != 1 {
+		t.Fatalf("expected state table to be rendered")
+	}
+	expected := [][]string{{"name", "string", "value"}}
+	if !reflect.DeepEqual(ui.tables[0], expected) {
+		t.Errorf("unexpected rows: %#v", ui.tables[0])
+	}
+
+	if !strings.Contains(ui.output[0], "after run of step 'stepA'") {
+		t.Errorf("unexpected output: %#v", ui.output)
+	}
+	if !strings.Contains(ui.output[1], "bogus") {
+		t.Errorf("expected unknown option output: %#v", ui.output)
+	}
+}
+
+func TestDebugInspectState_truncate(t *testing.T) {
+	ui := &testDebugUI{}
+	state := new(BasicStateBag)
+	state.Put("name", strings.Repeat("é", debugValueMaxLength+1))
+
+	DebugInspectState(ui, state)
+
+	value := ui.tables[0][0][2]
+	expected := strings.Repeat("é", debugValueMaxLength-3) + "..."
+	if value != expected {
+		t.Errorf("unexpected value: %q", value)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package multistep
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The maximum length of a value displayed when inspecting the state bag
+const debugValueMaxLength = 80
+
+// DebugMessageLevel is the level of a message displayed by a DebugUI
+type DebugMessageLevel int
+
+const (
+	DebugMessageHeader DebugMessageLevel = iota
+	DebugMessageWarning
+	DebugMessageError
+)
+
+// DebugUI is the user interface used by DebugPauseUI and
+// DebugInspectState. The multistep/debugui package provides a
+// DebugUI backed by a terminal.UI, so it can be used from within
+// a plugin.
+type DebugUI interface {
+	// Interactive returns true if the user can be prompted for input
+	Interactive() bool
+
+	// Ask prompts the user and returns the response
+	Ask(prompt string) (string, error)
+
+	// Message displays the message at the given level
+	Message(level DebugMessageLevel, msg string)
+
+	// Table displays the rows as a table with the given headers
+	Table(headers []string, rows [][]string)
+}
+
+// DebugPauseUI returns a pause function for the DebugRunner which uses
+// the provided UI to prompt the user for how to proceed. The user can
+// continue, skip, abort, or inspect the contents of the state bag.
+//
+// If the UI is not interactive, the pause location is displayed and
+// the runner continues.
+func DebugPauseUI(ui DebugUI) DebugPauseActionFn {
+	return func(loc DebugLocation, name string, state StateBag) DebugAction {
+		var locationString, skipString string
+		switch loc {
+		case DebugLocationAfterRun:
+			locationString = "after run of"
+			skipString = "[s]kip next step"
+		case DebugLocationBeforeCleanup:
+			locationString = "before cleanup of"
+			skipString = "[s]kip this cleanup"
+		}
+
+		ui.Message(DebugMessageHeader,
+			fmt.Sprintf("Pausing %s step '%s'.", locationString, name))
+
+		if !ui.Interactive() {
+			return DebugActionContinue
+		}
+
+		for {
+			result, err := ui.Ask(fmt.Sprintf(
+				"[c]ontinue, %s, [a]bort, [i]nspect state:", skipString))
+			if err != nil {
+				ui.Message(DebugMessageError,
+					fmt.Sprintf("Failed to read input: %s", err))
+				return DebugActionContinue
+			}
+
+			switch strings.ToLower(strings.TrimSpace(result)) {
+			case "", "c", "continue":
+				return DebugActionContinue
+			case "s", "skip":
+				return DebugActionSkip
+			case "a", "abort":
+				return DebugActionAbort
+			case "i", "inspect":
+				DebugInspectState(ui, state)
+			default:
+				ui.Message(DebugMessageWarning,
+					fmt.Sprintf("Unknown option '%s'", result))
+			}
+		}
+	}
+}
+
+// DebugInspectState renders the contents of the state bag as a table.
+// The state bag must implement ListableStateBag.
+func DebugInspectState(ui DebugUI, state StateBag) {
+	ls, ok := state.(ListableStateBag)
+	if !ok {
+		ui.Message(DebugMessageWarning,
+			fmt.Sprintf("State bag contents cannot be listed (%T)", state))
+		return
+	}
+
+	var rows [][]string
+	for _, k := range ls.Keys() {
+		v, ok := state.GetOk(k)
+		if !ok {
+			continue
+		}
+
+		value := fmt.Sprintf("%v", v)
+		value = strings.ReplaceAll(value, "\n", "\\n")
+		if runes := []rune(value); len(runes) > debugValueMaxLength {
+			value = string(runes[:debugValueMaxLength-3]) + "..."
+		}
+
+		rows = append(rows, []string{k, fmt.Sprintf("%T", v), value})
+	}
+
+	ui.Table([]string{"Key", "Type", "Value"}, rows)
+}
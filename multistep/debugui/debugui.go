@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package debugui adapts a terminal.UI for use with the debugging
+// helpers of the multistep package. It is kept separate so multistep
+// does not depend on the terminal package.
+package debugui
+
+import (
+	"github.com/hashicorp/vagrant-plugin-sdk/multistep"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+)
+
+// New returns a multistep.DebugUI which uses the provided UI
+func New(ui terminal.UI) multistep.DebugUI {
+	return &debugUI{ui: ui}
+}
+
+// PauseFn returns a pause function for the DebugRunner which uses
+// the provided UI. See multistep.DebugPauseUI.
+func PauseFn(ui terminal.UI) multistep.DebugPauseActionFn {
+	return multistep.DebugPauseUI(New(ui))
+}
+
+type debugUI struct {
+	ui terminal.UI
+}
+
+func (d *debugUI) Interactive() bool {
+	return d.ui.Interactive()
+}
+
+func (d *debugUI) Ask(prompt string) (string, error) {
+	return d.ui.Input(&terminal.Input{
+		Prompt: prompt,
+		Style:  terminal.InfoStyle,
+	})
+}
+
+func (d *debugUI) Message(level multistep.DebugMessageLevel, msg string) {
+	var opt terminal.Option
+	switch level {
+	case multistep.DebugMessageWarning:
+		opt = terminal.WithWarningStyle()
+	case multistep.DebugMessageError:
+		opt = terminal.WithErrorStyle()
+	default:
+		opt = terminal.WithHeaderStyle()
+	}
+
+	d.ui.Output(msg, opt)
+}
+
+func (d *debugUI) Table(headers []string, rows [][]string) {
+	tbl := terminal.NewTable(headers...)
+	for _, row := range rows {
+		tbl.Rich(row, nil)
+	}
+
+	d.ui.Table(tbl)
+}
+
+var _ multistep.DebugUI = (*debugUI)(nil)
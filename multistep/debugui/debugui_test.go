@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package debugui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/multistep"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+)
+
+type testUI struct {
+	terminal.UI
+
+	prompts []string
+	styles  []string
+	tables  []*terminal.Table
+}
+
+func (u *testUI) Interactive() bool { return true }
+
+func (u *testUI) Input(input *terminal.Input) (string, error) {
+	u.prompts = append(u.prompts, input.Prompt)
+	return "a", nil
+}
+
+func (u *testUI) Output(msg string, raw ...interface{}) {
+	_, style, _, _, _ := terminal.Interpret(msg, raw...)
+	u.styles = append(u.styles, style)
+}
+
+func (u *testUI) Table(tbl *terminal.Table, _ ...terminal.Option) {
+	u.tables = append(u.tables, tbl)
+}
+
+func TestPauseFn(t *testing.T) {
+	ui := &testUI{}
+	state := new(multistep.BasicStateBag)
+	state.Put("name", "value")
+
+	action := PauseFn(ui)(multistep.DebugLocationAfterRun, "stepA", state)
+	if action != multistep.DebugActionAbort {
+		t.Fatalf("unexpected action: %d", action)
+	}
+	if len(ui.prompts) != 1 {
+		t.Fatalf("unexpected prompts: %#v", ui.prompts)
+	}
+	if !reflect.DeepEqual(ui.styles, []string{terminal.HeaderStyle}) {
+		t.Errorf("unexpected styles: %#v", ui.styles)
+	}
+}
+
+func TestDebugUI_Table(t *testing.T) {
+	ui := &testUI{}
+	state := new(multistep.BasicStateBag)
+	state.Put("name", "value")
+
+	multistep.DebugInspectState(New(ui), state)
+
+	if len(ui.tables) != 1 {
+		t.Fatalf("expected state table to be rendered")
+	}
+	expected := [][]terminal.TableEntry{
+		{{Value: "name"}, {Value: "string"}, {Value: "value"}},
+	}
+	if !reflect.DeepEqual(ui.tables[0].Rows, expected) {
+		t.Errorf("unexpected rows: %#v", ui.tables[0].Rows)
+	}
+}
@@ -59,6 +59,26 @@ Value is 0
 Value is 1
 Value is 2
 ```
+
+## Typed Keys
+
+Instead of asserting the type of values pulled from the state bag, a
+typed key can be used. Keys are registered once and any attempt to
+register the same name again is an error.
+
+```go
+var valueKey = multistep.NewKey[int]("value")
+
+func (s *stepAdd) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+    value, err := valueKey.Get(state)
+    if err != nil {
+        // The value was missing or was not an int
+        return multistep.ActionHalt
+    }
+    valueKey.Put(state, value + 1)
+    return multistep.ActionContinue
+}
+```
 */
 
 package multistep
@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package multistep
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ErrKeyNotFound is returned when a typed key is requested from a
+// StateBag that does not contain it.
+var ErrKeyNotFound = errors.New("key not found in state bag")
+
+var errNilValue = errors.New("value is nil")
+
+// Key is a typed key for a value stored within a StateBag. Keys should
+// be created once (usually as package level variables) using NewKey so
+// that duplicate names are detected.
+//
+// Since core.StateBag and StateBag share the same method set, keys can
+// be used with either.
+type Key[T any] struct {
+	name string
+}
+
+// KeyError is returned when a typed key can not be read from a
+// StateBag.
+type KeyError struct {
+	Key      string       // name of the key
+	Expected reflect.Type // type registered for the key
+	Actual   reflect.Type // type of the value found, if any
+	Err      error        // underlying error
+}
+
+func (e *KeyError) Error() string {
+	if e.Actual != nil {
+		return fmt.Sprintf("state bag key %q: expected value of type %s but found %s",
+			e.Key, e.Expected, e.Actual)
+	}
+
+	return fmt.Sprintf("state bag key %q (%s): %s", e.Key, e.Expected, e.Err)
+}
+
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
+
+// DecodingStateBag is a StateBag that does not hold its values in memory
+// (like a StateBag provided to a plugin over gRPC). Values must be
+// converted into the requested type when fetched using a Key.
+type DecodingStateBag interface {
+	StateBag
+
+	// GetAs returns the value stored at key converted to the type
+	// of expectedType. Like mapping a value, expectedType is a nil
+	// pointer to the desired type, so `(*int)(nil)` is given when an
+	// `int` is wanted. The returned bool will be false if the key
+	// does not exist.
+	GetAs(key string, expectedType interface{}) (interface{}, bool, error)
+}
+
+var registry = struct {
+	sync.Mutex
+	keys map[string]reflect.Type
+}{keys: map[string]reflect.Type{}}
+
+// RegisterKey creates a new typed key. An error is returned if a key
+// with the same name has already been registered.
+func RegisterKey[T any](name string) (Key[T], error) {
+	typ := typeOf[T]()
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if existing, ok := registry.keys[name]; ok {
+		if existing != typ {
+			return Key[T]{}, fmt.Errorf(
+				"state bag key %q already registered with type %s, cannot register with type %s",
+				name, existing, typ)
+		}
+
+		return Key[T]{}, fmt.Errorf("state bag key %q already registered", name)
+	}
+
+	registry.keys[name] = typ
+
+	return Key[T]{name: name}, nil
+}
+
+// NewKey creates a new typed key. It panics if a key with the same
+// name has already been registered.
+func NewKey[T any](name string) Key[T] {
+	k, err := RegisterKey[T](name)
+	if err != nil {
+		panic(err)
+	}
+
+	return k
+}
+
+// KeyType returns the type registered for the named key.
+func KeyType(name string) (reflect.Type, bool) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	typ, ok := registry.keys[name]
+	return typ, ok
+}
+
+// Name returns the name of the key within the StateBag.
+func (k Key[T]) Name() string {
+	return k.name
+}
+
+// Get returns the value stored at the key. If the key does not
+// exist, or the stored value is not of the expected type, a
+// *KeyError is returned.
+func (k Key[T]) Get(state StateBag) (result T, err error) {
+	if ds, ok := state.(DecodingStateBag); ok {
+		v, ok, err := ds.GetAs(k.name, (*T)(nil))
+		if err != nil {
+			return result, k.error(nil, err)
+		}
+		if !ok {
+			return result, k.error(nil, ErrKeyNotFound)
+		}
+		if v == nil {
+			return result, nil
+		}
+		if result, ok = v.(T); !ok {
+			return result, k.error(reflect.TypeOf(v), nil)
+		}
+
+		return result, nil
+	}
+
+	raw, ok := state.GetOk(k.name)
+	if !ok {
+		return result, k.error(nil, ErrKeyNotFound)
+	}
+
+	if v, ok := raw.(T); ok {
+		return v, nil
+	}
+
+	if raw == nil {
+		switch typeOf[T]().Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+			return result, nil
+		}
+
+		return result, k.error(nil, errNilValue)
+	}
+
+	return result, k.error(reflect.TypeOf(raw), nil)
+}
+
+// MustGet returns the value stored at the key. It panics if the
+// value can not be returned.
+func (k Key[T]) MustGet(state StateBag) T {
+	v, err := k.Get(state)
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+// Put stores the value at the key.
+func (k Key[T]) Put(state StateBag, v T) {
+	state.Put(k.name, v)
+}
+
+func (k Key[T]) error(actual reflect.Type, err error) *KeyError {
+	return &KeyError{
+		Key:      k.name,
+		Expected: typeOf[T](),
+		Actual:   actual,
+		Err:      err,
+	}
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package multistep
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type testKeyValue struct {
+	Name  string
+	Count int
+}
+
+var (
+	testKeyString = NewKey[string]("test-key-string")
+	testKeyStruct = NewKey[*testKeyValue]("test-key-struct")
+)
+
+func TestKey_GetPut(t *testing.T) {
+	state := new(BasicStateBag)
+
+	if _, err := testKeyString.Get(state); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("expected not found error, got: %s", err)
+	}
+
+	testKeyString.Put(state, "value")
+	v, err := testKeyString.Get(state)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if v != "value" {
+		t.Fatalf("bad: %#v", v)
+	}
+}
+
+func TestKey_mistyped(t *testing.T) {
+	state := new(BasicStateBag)
+	state.Put(testKeyString.Name(), 42)
+
+	_, err := testKeyString.Get(state)
+	var kerr *KeyError
+	if !errors.As(err, &kerr) {
+		t.Fatalf("expected KeyError, got: %#v", err)
+	}
+	if kerr.Actual.String() != "int" {
+		t.Fatalf("bad actual type: %s", kerr.Actual)
+	}
+}
+
+func TestKey_MustGet(t *testing.T) {
+	state := new(BasicStateBag)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+
+	testKeyString.MustGet(state)
+}
+
+func TestKey_nilPointer(t *testing.T) {
+	state := new(BasicStateBag)
+	state.Put(testKeyStruct.Name(), nil)
+
+	v, err := testKeyStruct.Get(state)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if v != nil {
+		t.Fatalf("bad: %#v", v)
+	}
+}
+
+// testDecodingStateBag converts values like a remote StateBag
+// converting values using mappers
+type testDecodingStateBag struct {
+	BasicStateBag
+}
+
+func (b *testDecodingStateBag) GetAs(key string, expectedType interface{}) (interface{}, bool, error) {
+	raw, ok := b.GetOk(key)
+	if !ok {
+		return nil, false, nil
+	}
+	typ := reflect.TypeOf(expectedType).Elem()
+	v := reflect.ValueOf(raw)
+	if !v.Type().ConvertibleTo(typ) {
+		return nil, true, fmt.Errorf("cannot map %T to %s", raw, typ)
+	}
+	return v.Convert(typ).Interface(), true, nil
+}
+
+type testKeyName string
+
+func TestKey_decodingStateBag(t *testing.T) {
+	state := new(testDecodingStateBag)
+	key := NewKey[testKeyName]("test-key-name")
+	state.Put(key.Name(), "bar")
+
+	v, err := key.Get(state)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if v != "bar" {
+		t.Fatalf("bad: %#v", v)
+	}
+
+	if _, err := testKeyString.Get(state); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("expected not found error, got: %s", err)
+	}
+
+	state.Put(testKeyStruct.Name(), "bar")
+	_, err = testKeyStruct.Get(state)
+	var kerr *KeyError
+	if !errors.As(err, &kerr) {
+		t.Fatalf("expected KeyError, got: %#v", err)
+	}
+}
+
+func TestRegisterKey_duplicate(t *testing.T) {
+	if _, err := RegisterKey[string](testKeyString.Name()); err == nil {
+		t.Fatal("expected duplicate error")
+	}
+
+	if _, err := RegisterKey[int](testKeyString.Name()); err == nil {
+		t.Fatal("expected mistyped error")
+	}
+
+	typ, ok := KeyType(testKeyStruct.Name())
+	if !ok {
+		t.Fatal("expected key to be registered")
+	}
+	if typ.String() != "*multistep.testKeyValue" {
+		t.Fatalf("bad: %s", typ)
+	}
+}
@@ -56,6 +56,9 @@ type Step interface {
 	//
 	// The parameter is the same "state bag" as Run, and represents the
 	// state at the latest possible time prior to calling Cleanup.
+	//
+	// Steps which need a context or need to report a failure during
+	// cleanup can implement StepCleanupWithContext.
 	Cleanup(StateBag)
 }
 
@@ -3,7 +3,10 @@
 
 package multistep
 
-import "sync"
+import (
+	"sort"
+	"sync"
+)
 
 // Add context to state bag to prevent changing step signature
 
@@ -16,6 +19,14 @@ type StateBag interface {
 	Remove(string)
 }
 
+// ListableStateBag is a StateBag which can list the keys it contains.
+type ListableStateBag interface {
+	StateBag
+
+	// Keys returns the keys currently stored in the bag
+	Keys() []string
+}
+
 // BasicStateBag implements StateBag by using a normal map underneath
 // protected by a RWMutex.
 type BasicStateBag struct {
@@ -50,6 +61,20 @@ func (b *BasicStateBag) Put(k string, v interface{}) {
 	b.data[k] = v
 }
 
+// Keys implements ListableStateBag
+func (b *BasicStateBag) Keys() []string {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	keys := make([]string, 0, len(b.data))
+	for k := range b.data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
 func (b *BasicStateBag) Remove(k string) {
 	delete(b.data, k)
 }
@@ -28,6 +28,7 @@ const (
 	TerminalUIService_Events_FullMethodName            = "/hashicorp.vagrant.sdk.TerminalUIService/Events"
 	TerminalUIService_IsInteractive_FullMethodName     = "/hashicorp.vagrant.sdk.TerminalUIService/IsInteractive"
 	TerminalUIService_IsMachineReadable_FullMethodName = "/hashicorp.vagrant.sdk.TerminalUIService/IsMachineReadable"
+	TerminalUIService_Sensitive_FullMethodName         = "/hashicorp.vagrant.sdk.TerminalUIService/Sensitive"
 )
 
 // TerminalUIServiceClient is the client API for TerminalUIService service.
@@ -38,6 +39,7 @@ type TerminalUIServiceClient interface {
 	Events(ctx context.Context, opts ...grpc.CallOption) (TerminalUIService_EventsClient, error)
 	IsInteractive(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TerminalUI_IsInteractiveResponse, error)
 	IsMachineReadable(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TerminalUI_IsMachineReadableResponse, error)
+	Sensitive(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TerminalUI_SensitiveResponse, error)
 }
 
 type terminalUIServiceClient struct {
@@ -106,6 +108,15 @@ func (c *terminalUIServiceClient) IsMachineReadable(ctx context.Context, in *emp
 	return out, nil
 }
 
+func (c *terminalUIServiceClient) Sensitive(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TerminalUI_SensitiveResponse, error) {
+	out := new(TerminalUI_SensitiveResponse)
+	err := c.cc.Invoke(ctx, TerminalUIService_Sensitive_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TerminalUIServiceServer is the server API for TerminalUIService service.
 // All implementations should embed UnimplementedTerminalUIServiceServer
 // for forward compatibility
@@ -114,6 +125,7 @@ type TerminalUIServiceServer interface {
 	Events(TerminalUIService_EventsServer) error
 	IsInteractive(context.Context, *emptypb.Empty) (*TerminalUI_IsInteractiveResponse, error)
 	IsMachineReadable(context.Context, *emptypb.Empty) (*TerminalUI_IsMachineReadableResponse, error)
+	Sensitive(context.Context, *emptypb.Empty) (*TerminalUI_SensitiveResponse, error)
 }
 
 // UnimplementedTerminalUIServiceServer should be embedded to have forward compatible implementations.
@@ -132,6 +144,9 @@ func (UnimplementedTerminalUIServiceServer) IsInteractive(context.Context, *empt
 func (UnimplementedTerminalUIServiceServer) IsMachineReadable(context.Context, *emptypb.Empty) (*TerminalUI_IsMachineReadableResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsMachineReadable not implemented")
 }
+func (UnimplementedTerminalUIServiceServer) Sensitive(context.Context, *emptypb.Empty) (*TerminalUI_SensitiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sensitive not implemented")
+}
 
 // UnsafeTerminalUIServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to TerminalUIServiceServer will
@@ -224,6 +239,24 @@ func _TerminalUIService_IsMachineReadable_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TerminalUIService_Sensitive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerminalUIServiceServer).Sensitive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerminalUIService_Sensitive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerminalUIServiceServer).Sensitive(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TerminalUIService_ServiceDesc is the grpc.ServiceDesc for TerminalUIService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -243,6 +276,10 @@ var TerminalUIService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "IsMachineReadable",
 			Handler:    _TerminalUIService_IsMachineReadable_Handler,
 		},
+		{
+			MethodName: "Sensitive",
+			Handler:    _TerminalUIService_Sensitive_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -389,6 +426,7 @@ const (
 	StateBagService_GetOk_FullMethodName  = "/hashicorp.vagrant.sdk.StateBagService/GetOk"
 	StateBagService_Put_FullMethodName    = "/hashicorp.vagrant.sdk.StateBagService/Put"
 	StateBagService_Remove_FullMethodName = "/hashicorp.vagrant.sdk.StateBagService/Remove"
+	StateBagService_Keys_FullMethodName   = "/hashicorp.vagrant.sdk.StateBagService/Keys"
 )
 
 // StateBagServiceClient is the client API for StateBagService service.
@@ -399,6 +437,7 @@ type StateBagServiceClient interface {
 	GetOk(ctx context.Context, in *StateBag_GetRequest, opts ...grpc.CallOption) (*StateBag_GetOkResponse, error)
 	Put(ctx context.Context, in *StateBag_PutRequest, opts ...grpc.CallOption) (*StateBag_PutResponse, error)
 	Remove(ctx context.Context, in *StateBag_RemoveRequest, opts ...grpc.CallOption) (*StateBag_RemoveResponse, error)
+	Keys(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StateBag_KeysResponse, error)
 }
 
 type stateBagServiceClient struct {
@@ -445,6 +484,15 @@ func (c *stateBagServiceClient) Remove(ctx context.Context, in *StateBag_RemoveR
 	return out, nil
 }
 
+func (c *stateBagServiceClient) Keys(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StateBag_KeysResponse, error) {
+	out := new(StateBag_KeysResponse)
+	err := c.cc.Invoke(ctx, StateBagService_Keys_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StateBagServiceServer is the server API for StateBagService service.
 // All implementations should embed UnimplementedStateBagServiceServer
 // for forward compatibility
@@ -453,6 +501,7 @@ type StateBagServiceServer interface {
 	GetOk(context.Context, *StateBag_GetRequest) (*StateBag_GetOkResponse, error)
 	Put(context.Context, *StateBag_PutRequest) (*StateBag_PutResponse, error)
 	Remove(context.Context, *StateBag_RemoveRequest) (*StateBag_RemoveResponse, error)
+	Keys(context.Context, *emptypb.Empty) (*StateBag_KeysResponse, error)
 }
 
 // UnimplementedStateBagServiceServer should be embedded to have forward compatible implementations.
@@ -471,6 +520,9 @@ func (UnimplementedStateBagServiceServer) Put(context.Context, *StateBag_PutRequ
 func (UnimplementedStateBagServiceServer) Remove(context.Context, *StateBag_RemoveRequest) (*StateBag_RemoveResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
 }
+func (UnimplementedStateBagServiceServer) Keys(context.Context, *emptypb.Empty) (*StateBag_KeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Keys not implemented")
+}
 
 // UnsafeStateBagServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to StateBagServiceServer will
@@ -555,6 +607,24 @@ func _StateBagService_Remove_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StateBagService_Keys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateBagServiceServer).Keys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StateBagService_Keys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateBagServiceServer).Keys(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StateBagService_ServiceDesc is the grpc.ServiceDesc for StateBagService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -578,6 +648,135 @@ var StateBagService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Remove",
 			Handler:    _StateBagService_Remove_Handler,
 		},
+		{
+			MethodName: "Keys",
+			Handler:    _StateBagService_Keys_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "vagrant_plugin_sdk/plugin.proto",
+}
+
+const (
+	FunctionService_Spec_FullMethodName = "/hashicorp.vagrant.sdk.FunctionService/Spec"
+	FunctionService_Call_FullMethodName = "/hashicorp.vagrant.sdk.FunctionService/Call"
+)
+
+// FunctionServiceClient is the client API for FunctionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FunctionServiceClient interface {
+	Spec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Function_SpecList, error)
+	Call(ctx context.Context, in *Function_CallRequest, opts ...grpc.CallOption) (*Function_CallResponse, error)
+}
+
+type functionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFunctionServiceClient(cc grpc.ClientConnInterface) FunctionServiceClient {
+	return &functionServiceClient{cc}
+}
+
+func (c *functionServiceClient) Spec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Function_SpecList, error) {
+	out := new(Function_SpecList)
+	err := c.cc.Invoke(ctx, FunctionService_Spec_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *functionServiceClient) Call(ctx context.Context, in *Function_CallRequest, opts ...grpc.CallOption) (*Function_CallResponse, error) {
+	out := new(Function_CallResponse)
+	err := c.cc.Invoke(ctx, FunctionService_Call_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FunctionServiceServer is the server API for FunctionService service.
+// All implementations should embed UnimplementedFunctionServiceServer
+// for forward compatibility
+type FunctionServiceServer interface {
+	Spec(context.Context, *emptypb.Empty) (*Function_SpecList, error)
+	Call(context.Context, *Function_CallRequest) (*Function_CallResponse, error)
+}
+
+// UnimplementedFunctionServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedFunctionServiceServer struct {
+}
+
+func (UnimplementedFunctionServiceServer) Spec(context.Context, *emptypb.Empty) (*Function_SpecList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Spec not implemented")
+}
+func (UnimplementedFunctionServiceServer) Call(context.Context, *Function_CallRequest) (*Function_CallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Call not implemented")
+}
+
+// UnsafeFunctionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FunctionServiceServer will
+// result in compilation errors.
+type UnsafeFunctionServiceServer interface {
+	mustEmbedUnimplementedFunctionServiceServer()
+}
+
+func RegisterFunctionServiceServer(s grpc.ServiceRegistrar, srv FunctionServiceServer) {
+	s.RegisterService(&FunctionService_ServiceDesc, srv)
+}
+
+func _FunctionService_Spec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FunctionServiceServer).Spec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FunctionService_Spec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FunctionServiceServer).Spec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FunctionService_Call_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Function_CallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FunctionServiceServer).Call(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FunctionService_Call_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FunctionServiceServer).Call(ctx, req.(*Function_CallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FunctionService_ServiceDesc is the grpc.ServiceDesc for FunctionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FunctionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.vagrant.sdk.FunctionService",
+	HandlerType: (*FunctionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Spec",
+			Handler:    _FunctionService_Spec_Handler,
+		},
+		{
+			MethodName: "Call",
+			Handler:    _FunctionService_Call_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vagrant_plugin_sdk/plugin.proto",
@@ -979,6 +1178,7 @@ const (
 	ProviderService_Seeds_FullMethodName                = "/hashicorp.vagrant.sdk.ProviderService/Seeds"
 	ProviderService_SetPluginName_FullMethodName        = "/hashicorp.vagrant.sdk.ProviderService/SetPluginName"
 	ProviderService_PluginName_FullMethodName           = "/hashicorp.vagrant.sdk.ProviderService/PluginName"
+	ProviderService_Documentation_FullMethodName        = "/hashicorp.vagrant.sdk.ProviderService/Documentation"
 )
 
 // ProviderServiceClient is the client API for ProviderService service.
@@ -1005,6 +1205,7 @@ type ProviderServiceClient interface {
 	Seeds(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Args_Seeds, error)
 	SetPluginName(ctx context.Context, in *PluginInfo_Name, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	PluginName(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PluginInfo_Name, error)
+	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
 }
 
 type providerServiceClient struct {
@@ -1195,6 +1396,15 @@ func (c *providerServiceClient) PluginName(ctx context.Context, in *emptypb.Empt
 	return out, nil
 }
 
+func (c *providerServiceClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
+	out := new(Config_Documentation)
+	err := c.cc.Invoke(ctx, ProviderService_Documentation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProviderServiceServer is the server API for ProviderService service.
 // All implementations should embed UnimplementedProviderServiceServer
 // for forward compatibility
@@ -1219,6 +1429,7 @@ type ProviderServiceServer interface {
 	Seeds(context.Context, *emptypb.Empty) (*Args_Seeds, error)
 	SetPluginName(context.Context, *PluginInfo_Name) (*emptypb.Empty, error)
 	PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error)
+	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
 }
 
 // UnimplementedProviderServiceServer should be embedded to have forward compatible implementations.
@@ -1285,6 +1496,9 @@ func (UnimplementedProviderServiceServer) SetPluginName(context.Context, *Plugin
 func (UnimplementedProviderServiceServer) PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PluginName not implemented")
 }
+func (UnimplementedProviderServiceServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
+}
 
 // UnsafeProviderServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to ProviderServiceServer will
@@ -1657,6 +1871,24 @@ func _ProviderService_PluginName_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProviderService_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServiceServer).Documentation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProviderService_Documentation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServiceServer).Documentation(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProviderService_ServiceDesc is the grpc.ServiceDesc for ProviderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1744,6 +1976,10 @@ var ProviderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PluginName",
 			Handler:    _ProviderService_PluginName_Handler,
 		},
+		{
+			MethodName: "Documentation",
+			Handler:    _ProviderService_Documentation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vagrant_plugin_sdk/plugin.proto",
@@ -1760,6 +1996,7 @@ const (
 	ProvisionerService_Seeds_FullMethodName         = "/hashicorp.vagrant.sdk.ProvisionerService/Seeds"
 	ProvisionerService_SetPluginName_FullMethodName = "/hashicorp.vagrant.sdk.ProvisionerService/SetPluginName"
 	ProvisionerService_PluginName_FullMethodName    = "/hashicorp.vagrant.sdk.ProvisionerService/PluginName"
+	ProvisionerService_Documentation_FullMethodName = "/hashicorp.vagrant.sdk.ProvisionerService/Documentation"
 )
 
 // ProvisionerServiceClient is the client API for ProvisionerService service.
@@ -1776,6 +2013,7 @@ type ProvisionerServiceClient interface {
 	Seeds(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Args_Seeds, error)
 	SetPluginName(ctx context.Context, in *PluginInfo_Name, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	PluginName(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PluginInfo_Name, error)
+	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
 }
 
 type provisionerServiceClient struct {
@@ -1876,6 +2114,15 @@ func (c *provisionerServiceClient) PluginName(ctx context.Context, in *emptypb.E
 	return out, nil
 }
 
+func (c *provisionerServiceClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
+	out := new(Config_Documentation)
+	err := c.cc.Invoke(ctx, ProvisionerService_Documentation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProvisionerServiceServer is the server API for ProvisionerService service.
 // All implementations should embed UnimplementedProvisionerServiceServer
 // for forward compatibility
@@ -1890,6 +2137,7 @@ type ProvisionerServiceServer interface {
 	Seeds(context.Context, *emptypb.Empty) (*Args_Seeds, error)
 	SetPluginName(context.Context, *PluginInfo_Name) (*emptypb.Empty, error)
 	PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error)
+	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
 }
 
 // UnimplementedProvisionerServiceServer should be embedded to have forward compatible implementations.
@@ -1926,6 +2174,9 @@ func (UnimplementedProvisionerServiceServer) SetPluginName(context.Context, *Plu
 func (UnimplementedProvisionerServiceServer) PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PluginName not implemented")
 }
+func (UnimplementedProvisionerServiceServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
+}
 
 // UnsafeProvisionerServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to ProvisionerServiceServer will
@@ -2118,6 +2369,24 @@ func _ProvisionerService_PluginName_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProvisionerService_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionerServiceServer).Documentation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProvisionerService_Documentation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionerServiceServer).Documentation(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProvisionerService_ServiceDesc is the grpc.ServiceDesc for ProvisionerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -2165,6 +2434,10 @@ var ProvisionerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PluginName",
 			Handler:    _ProvisionerService_PluginName_Handler,
 		},
+		{
+			MethodName: "Documentation",
+			Handler:    _ProvisionerService_Documentation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vagrant_plugin_sdk/plugin.proto",
@@ -2179,6 +2452,7 @@ const (
 	CommandService_Seeds_FullMethodName           = "/hashicorp.vagrant.sdk.CommandService/Seeds"
 	CommandService_SetPluginName_FullMethodName   = "/hashicorp.vagrant.sdk.CommandService/SetPluginName"
 	CommandService_PluginName_FullMethodName      = "/hashicorp.vagrant.sdk.CommandService/PluginName"
+	CommandService_Documentation_FullMethodName   = "/hashicorp.vagrant.sdk.CommandService/Documentation"
 )
 
 // CommandServiceClient is the client API for CommandService service.
@@ -2193,6 +2467,7 @@ type CommandServiceClient interface {
 	Seeds(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Args_Seeds, error)
 	SetPluginName(ctx context.Context, in *PluginInfo_Name, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	PluginName(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PluginInfo_Name, error)
+	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
 }
 
 type commandServiceClient struct {
@@ -2275,6 +2550,15 @@ func (c *commandServiceClient) PluginName(ctx context.Context, in *emptypb.Empty
 	return out, nil
 }
 
+func (c *commandServiceClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
+	out := new(Config_Documentation)
+	err := c.cc.Invoke(ctx, CommandService_Documentation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CommandServiceServer is the server API for CommandService service.
 // All implementations should embed UnimplementedCommandServiceServer
 // for forward compatibility
@@ -2287,6 +2571,7 @@ type CommandServiceServer interface {
 	Seeds(context.Context, *emptypb.Empty) (*Args_Seeds, error)
 	SetPluginName(context.Context, *PluginInfo_Name) (*emptypb.Empty, error)
 	PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error)
+	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
 }
 
 // UnimplementedCommandServiceServer should be embedded to have forward compatible implementations.
@@ -2317,6 +2602,9 @@ func (UnimplementedCommandServiceServer) SetPluginName(context.Context, *PluginI
 func (UnimplementedCommandServiceServer) PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PluginName not implemented")
 }
+func (UnimplementedCommandServiceServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
+}
 
 // UnsafeCommandServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to CommandServiceServer will
@@ -2473,6 +2761,24 @@ func _CommandService_PluginName_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommandService_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommandServiceServer).Documentation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommandService_Documentation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommandServiceServer).Documentation(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CommandService_ServiceDesc is the grpc.ServiceDesc for CommandService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -2512,6 +2818,10 @@ var CommandService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PluginName",
 			Handler:    _CommandService_PluginName_Handler,
 		},
+		{
+			MethodName: "Documentation",
+			Handler:    _CommandService_Documentation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vagrant_plugin_sdk/plugin.proto",
@@ -2542,6 +2852,7 @@ const (
 	CommunicatorService_Seeds_FullMethodName                 = "/hashicorp.vagrant.sdk.CommunicatorService/Seeds"
 	CommunicatorService_SetPluginName_FullMethodName         = "/hashicorp.vagrant.sdk.CommunicatorService/SetPluginName"
 	CommunicatorService_PluginName_FullMethodName            = "/hashicorp.vagrant.sdk.CommunicatorService/PluginName"
+	CommunicatorService_Documentation_FullMethodName         = "/hashicorp.vagrant.sdk.CommunicatorService/Documentation"
 )
 
 // CommunicatorServiceClient is the client API for CommunicatorService service.
@@ -2572,6 +2883,7 @@ type CommunicatorServiceClient interface {
 	Seeds(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Args_Seeds, error)
 	SetPluginName(ctx context.Context, in *PluginInfo_Name, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	PluginName(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PluginInfo_Name, error)
+	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
 }
 
 type communicatorServiceClient struct {
@@ -2798,6 +3110,15 @@ func (c *communicatorServiceClient) PluginName(ctx context.Context, in *emptypb.
 	return out, nil
 }
 
+func (c *communicatorServiceClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
+	out := new(Config_Documentation)
+	err := c.cc.Invoke(ctx, CommunicatorService_Documentation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CommunicatorServiceServer is the server API for CommunicatorService service.
 // All implementations should embed UnimplementedCommunicatorServiceServer
 // for forward compatibility
@@ -2826,6 +3147,7 @@ type CommunicatorServiceServer interface {
 	Seeds(context.Context, *emptypb.Empty) (*Args_Seeds, error)
 	SetPluginName(context.Context, *PluginInfo_Name) (*emptypb.Empty, error)
 	PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error)
+	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
 }
 
 // UnimplementedCommunicatorServiceServer should be embedded to have forward compatible implementations.
@@ -2904,6 +3226,9 @@ func (UnimplementedCommunicatorServiceServer) SetPluginName(context.Context, *Pl
 func (UnimplementedCommunicatorServiceServer) PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PluginName not implemented")
 }
+func (UnimplementedCommunicatorServiceServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
+}
 
 // UnsafeCommunicatorServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to CommunicatorServiceServer will
@@ -3348,6 +3673,24 @@ func _CommunicatorService_PluginName_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommunicatorService_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommunicatorServiceServer).Documentation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommunicatorService_Documentation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommunicatorServiceServer).Documentation(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CommunicatorService_ServiceDesc is the grpc.ServiceDesc for CommunicatorService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -3451,6 +3794,10 @@ var CommunicatorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PluginName",
 			Handler:    _CommunicatorService_PluginName_Handler,
 		},
+		{
+			MethodName: "Documentation",
+			Handler:    _CommunicatorService_Documentation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vagrant_plugin_sdk/plugin.proto",
@@ -3470,6 +3817,7 @@ const (
 	ConfigService_Seeds_FullMethodName         = "/hashicorp.vagrant.sdk.ConfigService/Seeds"
 	ConfigService_SetPluginName_FullMethodName = "/hashicorp.vagrant.sdk.ConfigService/SetPluginName"
 	ConfigService_PluginName_FullMethodName    = "/hashicorp.vagrant.sdk.ConfigService/PluginName"
+	ConfigService_Documentation_FullMethodName = "/hashicorp.vagrant.sdk.ConfigService/Documentation"
 )
 
 // ConfigServiceClient is the client API for ConfigService service.
@@ -3489,6 +3837,7 @@ type ConfigServiceClient interface {
 	Seeds(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Args_Seeds, error)
 	SetPluginName(ctx context.Context, in *PluginInfo_Name, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	PluginName(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PluginInfo_Name, error)
+	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
 }
 
 type configServiceClient struct {
@@ -3616,6 +3965,15 @@ func (c *configServiceClient) PluginName(ctx context.Context, in *emptypb.Empty,
 	return out, nil
 }
 
+func (c *configServiceClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
+	out := new(Config_Documentation)
+	err := c.cc.Invoke(ctx, ConfigService_Documentation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ConfigServiceServer is the server API for ConfigService service.
 // All implementations should embed UnimplementedConfigServiceServer
 // for forward compatibility
@@ -3633,6 +3991,7 @@ type ConfigServiceServer interface {
 	Seeds(context.Context, *emptypb.Empty) (*Args_Seeds, error)
 	SetPluginName(context.Context, *PluginInfo_Name) (*emptypb.Empty, error)
 	PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error)
+	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
 }
 
 // UnimplementedConfigServiceServer should be embedded to have forward compatible implementations.
@@ -3678,6 +4037,9 @@ func (UnimplementedConfigServiceServer) SetPluginName(context.Context, *PluginIn
 func (UnimplementedConfigServiceServer) PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PluginName not implemented")
 }
+func (UnimplementedConfigServiceServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
+}
 
 // UnsafeConfigServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to ConfigServiceServer will
@@ -3924,6 +4286,24 @@ func _ConfigService_PluginName_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ConfigService_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).Documentation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_Documentation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).Documentation(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ConfigService_ServiceDesc is the grpc.ServiceDesc for ConfigService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -3983,6 +4363,10 @@ var ConfigService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PluginName",
 			Handler:    _ConfigService_PluginName_Handler,
 		},
+		{
+			MethodName: "Documentation",
+			Handler:    _ConfigService_Documentation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vagrant_plugin_sdk/plugin.proto",
@@ -4001,6 +4385,7 @@ const (
 	HostService_Seeds_FullMethodName             = "/hashicorp.vagrant.sdk.HostService/Seeds"
 	HostService_SetPluginName_FullMethodName     = "/hashicorp.vagrant.sdk.HostService/SetPluginName"
 	HostService_PluginName_FullMethodName        = "/hashicorp.vagrant.sdk.HostService/PluginName"
+	HostService_Documentation_FullMethodName     = "/hashicorp.vagrant.sdk.HostService/Documentation"
 )
 
 // HostServiceClient is the client API for HostService service.
@@ -4019,6 +4404,7 @@ type HostServiceClient interface {
 	Seeds(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Args_Seeds, error)
 	SetPluginName(ctx context.Context, in *PluginInfo_Name, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	PluginName(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PluginInfo_Name, error)
+	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
 }
 
 type hostServiceClient struct {
@@ -4137,6 +4523,15 @@ func (c *hostServiceClient) PluginName(ctx context.Context, in *emptypb.Empty, o
 	return out, nil
 }
 
+func (c *hostServiceClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
+	out := new(Config_Documentation)
+	err := c.cc.Invoke(ctx, HostService_Documentation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HostServiceServer is the server API for HostService service.
 // All implementations should embed UnimplementedHostServiceServer
 // for forward compatibility
@@ -4153,6 +4548,7 @@ type HostServiceServer interface {
 	Seeds(context.Context, *emptypb.Empty) (*Args_Seeds, error)
 	SetPluginName(context.Context, *PluginInfo_Name) (*emptypb.Empty, error)
 	PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error)
+	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
 }
 
 // UnimplementedHostServiceServer should be embedded to have forward compatible implementations.
@@ -4195,6 +4591,9 @@ func (UnimplementedHostServiceServer) SetPluginName(context.Context, *PluginInfo
 func (UnimplementedHostServiceServer) PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PluginName not implemented")
 }
+func (UnimplementedHostServiceServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
+}
 
 // UnsafeHostServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to HostServiceServer will
@@ -4423,6 +4822,24 @@ func _HostService_PluginName_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HostService_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).Documentation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_Documentation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).Documentation(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // HostService_ServiceDesc is the grpc.ServiceDesc for HostService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -4478,6 +4895,10 @@ var HostService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PluginName",
 			Handler:    _HostService_PluginName_Handler,
 		},
+		{
+			MethodName: "Documentation",
+			Handler:    _HostService_Documentation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vagrant_plugin_sdk/plugin.proto",
@@ -4496,6 +4917,7 @@ const (
 	GuestService_Seeds_FullMethodName             = "/hashicorp.vagrant.sdk.GuestService/Seeds"
 	GuestService_SetPluginName_FullMethodName     = "/hashicorp.vagrant.sdk.GuestService/SetPluginName"
 	GuestService_PluginName_FullMethodName        = "/hashicorp.vagrant.sdk.GuestService/PluginName"
+	GuestService_Documentation_FullMethodName     = "/hashicorp.vagrant.sdk.GuestService/Documentation"
 )
 
 // GuestServiceClient is the client API for GuestService service.
@@ -4514,6 +4936,7 @@ type GuestServiceClient interface {
 	Seeds(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Args_Seeds, error)
 	SetPluginName(ctx context.Context, in *PluginInfo_Name, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	PluginName(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PluginInfo_Name, error)
+	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
 }
 
 type guestServiceClient struct {
@@ -4632,6 +5055,15 @@ func (c *guestServiceClient) PluginName(ctx context.Context, in *emptypb.Empty,
 	return out, nil
 }
 
+func (c *guestServiceClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
+	out := new(Config_Documentation)
+	err := c.cc.Invoke(ctx, GuestService_Documentation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GuestServiceServer is the server API for GuestService service.
 // All implementations should embed UnimplementedGuestServiceServer
 // for forward compatibility
@@ -4648,6 +5080,7 @@ type GuestServiceServer interface {
 	Seeds(context.Context, *emptypb.Empty) (*Args_Seeds, error)
 	SetPluginName(context.Context, *PluginInfo_Name) (*emptypb.Empty, error)
 	PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error)
+	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
 }
 
 // UnimplementedGuestServiceServer should be embedded to have forward compatible implementations.
@@ -4690,6 +5123,9 @@ func (UnimplementedGuestServiceServer) SetPluginName(context.Context, *PluginInf
 func (UnimplementedGuestServiceServer) PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PluginName not implemented")
 }
+func (UnimplementedGuestServiceServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
+}
 
 // UnsafeGuestServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to GuestServiceServer will
@@ -4918,6 +5354,24 @@ func _GuestService_PluginName_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GuestService_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GuestServiceServer).Documentation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GuestService_Documentation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GuestServiceServer).Documentation(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GuestService_ServiceDesc is the grpc.ServiceDesc for GuestService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -4973,6 +5427,10 @@ var GuestService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PluginName",
 			Handler:    _GuestService_PluginName_Handler,
 		},
+		{
+			MethodName: "Documentation",
+			Handler:    _GuestService_Documentation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vagrant_plugin_sdk/plugin.proto",
@@ -4997,6 +5455,7 @@ const (
 	SyncedFolderService_Seeds_FullMethodName             = "/hashicorp.vagrant.sdk.SyncedFolderService/Seeds"
 	SyncedFolderService_SetPluginName_FullMethodName     = "/hashicorp.vagrant.sdk.SyncedFolderService/SetPluginName"
 	SyncedFolderService_PluginName_FullMethodName        = "/hashicorp.vagrant.sdk.SyncedFolderService/PluginName"
+	SyncedFolderService_Documentation_FullMethodName     = "/hashicorp.vagrant.sdk.SyncedFolderService/Documentation"
 )
 
 // SyncedFolderServiceClient is the client API for SyncedFolderService service.
@@ -5021,6 +5480,7 @@ type SyncedFolderServiceClient interface {
 	Seeds(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Args_Seeds, error)
 	SetPluginName(ctx context.Context, in *PluginInfo_Name, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	PluginName(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PluginInfo_Name, error)
+	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
 }
 
 type syncedFolderServiceClient struct {
@@ -5193,6 +5653,15 @@ func (c *syncedFolderServiceClient) PluginName(ctx context.Context, in *emptypb.
 	return out, nil
 }
 
+func (c *syncedFolderServiceClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
+	out := new(Config_Documentation)
+	err := c.cc.Invoke(ctx, SyncedFolderService_Documentation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SyncedFolderServiceServer is the server API for SyncedFolderService service.
 // All implementations should embed UnimplementedSyncedFolderServiceServer
 // for forward compatibility
@@ -5215,6 +5684,7 @@ type SyncedFolderServiceServer interface {
 	Seeds(context.Context, *emptypb.Empty) (*Args_Seeds, error)
 	SetPluginName(context.Context, *PluginInfo_Name) (*emptypb.Empty, error)
 	PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error)
+	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
 }
 
 // UnimplementedSyncedFolderServiceServer should be embedded to have forward compatible implementations.
@@ -5275,6 +5745,9 @@ func (UnimplementedSyncedFolderServiceServer) SetPluginName(context.Context, *Pl
 func (UnimplementedSyncedFolderServiceServer) PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PluginName not implemented")
 }
+func (UnimplementedSyncedFolderServiceServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
+}
 
 // UnsafeSyncedFolderServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to SyncedFolderServiceServer will
@@ -5611,6 +6084,24 @@ func _SyncedFolderService_PluginName_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SyncedFolderService_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncedFolderServiceServer).Documentation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SyncedFolderService_Documentation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncedFolderServiceServer).Documentation(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SyncedFolderService_ServiceDesc is the grpc.ServiceDesc for SyncedFolderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -5690,6 +6181,10 @@ var SyncedFolderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PluginName",
 			Handler:    _SyncedFolderService_PluginName_Handler,
 		},
+		{
+			MethodName: "Documentation",
+			Handler:    _SyncedFolderService_Documentation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vagrant_plugin_sdk/plugin.proto",
@@ -8938,12 +9433,13 @@ var ProjectService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	VagrantfileService_GetValue_FullMethodName          = "/hashicorp.vagrant.sdk.VagrantfileService/GetValue"
-	VagrantfileService_GetConfig_FullMethodName         = "/hashicorp.vagrant.sdk.VagrantfileService/GetConfig"
-	VagrantfileService_Target_FullMethodName            = "/hashicorp.vagrant.sdk.VagrantfileService/Target"
-	VagrantfileService_TargetConfig_FullMethodName      = "/hashicorp.vagrant.sdk.VagrantfileService/TargetConfig"
-	VagrantfileService_TargetNames_FullMethodName       = "/hashicorp.vagrant.sdk.VagrantfileService/TargetNames"
-	VagrantfileService_PrimaryTargetName_FullMethodName = "/hashicorp.vagrant.sdk.VagrantfileService/PrimaryTargetName"
+	VagrantfileService_GetValue_FullMethodName           = "/hashicorp.vagrant.sdk.VagrantfileService/GetValue"
+	VagrantfileService_GetConfig_FullMethodName          = "/hashicorp.vagrant.sdk.VagrantfileService/GetConfig"
+	VagrantfileService_GetValueProvenance_FullMethodName = "/hashicorp.vagrant.sdk.VagrantfileService/GetValueProvenance"
+	VagrantfileService_Target_FullMethodName             = "/hashicorp.vagrant.sdk.VagrantfileService/Target"
+	VagrantfileService_TargetConfig_FullMethodName       = "/hashicorp.vagrant.sdk.VagrantfileService/TargetConfig"
+	VagrantfileService_TargetNames_FullMethodName        = "/hashicorp.vagrant.sdk.VagrantfileService/TargetNames"
+	VagrantfileService_PrimaryTargetName_FullMethodName  = "/hashicorp.vagrant.sdk.VagrantfileService/PrimaryTargetName"
 )
 
 // VagrantfileServiceClient is the client API for VagrantfileService service.
@@ -8952,6 +9448,7 @@ const (
 type VagrantfileServiceClient interface {
 	GetValue(ctx context.Context, in *Vagrantfile_ValueRequest, opts ...grpc.CallOption) (*Args_Direct, error)
 	GetConfig(ctx context.Context, in *Vagrantfile_NamespaceRequest, opts ...grpc.CallOption) (*Args_ConfigData, error)
+	GetValueProvenance(ctx context.Context, in *Vagrantfile_ValueRequest, opts ...grpc.CallOption) (*Args_Provenance, error)
 	Target(ctx context.Context, in *Vagrantfile_TargetRequest, opts ...grpc.CallOption) (*Args_Target, error)
 	TargetConfig(ctx context.Context, in *Vagrantfile_TargetConfigRequest, opts ...grpc.CallOption) (*Args_Vagrantfile, error)
 	TargetNames(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Vagrantfile_TargetNamesResponse, error)
@@ -8984,6 +9481,15 @@ func (c *vagrantfileServiceClient) GetConfig(ctx context.Context, in *Vagrantfil
 	return out, nil
 }
 
+func (c *vagrantfileServiceClient) GetValueProvenance(ctx context.Context, in *Vagrantfile_ValueRequest, opts ...grpc.CallOption) (*Args_Provenance, error) {
+	out := new(Args_Provenance)
+	err := c.cc.Invoke(ctx, VagrantfileService_GetValueProvenance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vagrantfileServiceClient) Target(ctx context.Context, in *Vagrantfile_TargetRequest, opts ...grpc.CallOption) (*Args_Target, error) {
 	out := new(Args_Target)
 	err := c.cc.Invoke(ctx, VagrantfileService_Target_FullMethodName, in, out, opts...)
@@ -9026,6 +9532,7 @@ func (c *vagrantfileServiceClient) PrimaryTargetName(ctx context.Context, in *em
 type VagrantfileServiceServer interface {
 	GetValue(context.Context, *Vagrantfile_ValueRequest) (*Args_Direct, error)
 	GetConfig(context.Context, *Vagrantfile_NamespaceRequest) (*Args_ConfigData, error)
+	GetValueProvenance(context.Context, *Vagrantfile_ValueRequest) (*Args_Provenance, error)
 	Target(context.Context, *Vagrantfile_TargetRequest) (*Args_Target, error)
 	TargetConfig(context.Context, *Vagrantfile_TargetConfigRequest) (*Args_Vagrantfile, error)
 	TargetNames(context.Context, *emptypb.Empty) (*Vagrantfile_TargetNamesResponse, error)
@@ -9042,6 +9549,9 @@ func (UnimplementedVagrantfileServiceServer) GetValue(context.Context, *Vagrantf
 func (UnimplementedVagrantfileServiceServer) GetConfig(context.Context, *Vagrantfile_NamespaceRequest) (*Args_ConfigData, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
 }
+func (UnimplementedVagrantfileServiceServer) GetValueProvenance(context.Context, *Vagrantfile_ValueRequest) (*Args_Provenance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValueProvenance not implemented")
+}
 func (UnimplementedVagrantfileServiceServer) Target(context.Context, *Vagrantfile_TargetRequest) (*Args_Target, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Target not implemented")
 }
@@ -9102,6 +9612,24 @@ func _VagrantfileService_GetConfig_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VagrantfileService_GetValueProvenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Vagrantfile_ValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VagrantfileServiceServer).GetValueProvenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VagrantfileService_GetValueProvenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VagrantfileServiceServer).GetValueProvenance(ctx, req.(*Vagrantfile_ValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VagrantfileService_Target_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Vagrantfile_TargetRequest)
 	if err := dec(in); err != nil {
@@ -9189,6 +9717,10 @@ var VagrantfileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetConfig",
 			Handler:    _VagrantfileService_GetConfig_Handler,
 		},
+		{
+			MethodName: "GetValueProvenance",
+			Handler:    _VagrantfileService_GetValueProvenance_Handler,
+		},
 		{
 			MethodName: "Target",
 			Handler:    _VagrantfileService_Target_Handler,
@@ -10531,6 +11063,7 @@ const (
 	PushService_Seeds_FullMethodName         = "/hashicorp.vagrant.sdk.PushService/Seeds"
 	PushService_SetPluginName_FullMethodName = "/hashicorp.vagrant.sdk.PushService/SetPluginName"
 	PushService_PluginName_FullMethodName    = "/hashicorp.vagrant.sdk.PushService/PluginName"
+	PushService_Documentation_FullMethodName = "/hashicorp.vagrant.sdk.PushService/Documentation"
 )
 
 // PushServiceClient is the client API for PushService service.
@@ -10543,6 +11076,7 @@ type PushServiceClient interface {
 	Seeds(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Args_Seeds, error)
 	SetPluginName(ctx context.Context, in *PluginInfo_Name, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	PluginName(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PluginInfo_Name, error)
+	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
 }
 
 type pushServiceClient struct {
@@ -10607,6 +11141,15 @@ func (c *pushServiceClient) PluginName(ctx context.Context, in *emptypb.Empty, o
 	return out, nil
 }
 
+func (c *pushServiceClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
+	out := new(Config_Documentation)
+	err := c.cc.Invoke(ctx, PushService_Documentation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PushServiceServer is the server API for PushService service.
 // All implementations should embed UnimplementedPushServiceServer
 // for forward compatibility
@@ -10617,6 +11160,7 @@ type PushServiceServer interface {
 	Seeds(context.Context, *emptypb.Empty) (*Args_Seeds, error)
 	SetPluginName(context.Context, *PluginInfo_Name) (*emptypb.Empty, error)
 	PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error)
+	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
 }
 
 // UnimplementedPushServiceServer should be embedded to have forward compatible implementations.
@@ -10641,6 +11185,9 @@ func (UnimplementedPushServiceServer) SetPluginName(context.Context, *PluginInfo
 func (UnimplementedPushServiceServer) PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PluginName not implemented")
 }
+func (UnimplementedPushServiceServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
+}
 
 // UnsafePushServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to PushServiceServer will
@@ -10761,6 +11308,24 @@ func _PushService_PluginName_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PushService_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PushServiceServer).Documentation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PushService_Documentation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PushServiceServer).Documentation(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PushService_ServiceDesc is the grpc.ServiceDesc for PushService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -10792,6 +11357,10 @@ var PushService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PluginName",
 			Handler:    _PushService_PluginName_Handler,
 		},
+		{
+			MethodName: "Documentation",
+			Handler:    _PushService_Documentation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vagrant_plugin_sdk/plugin.proto",
@@ -10804,6 +11373,7 @@ const (
 	DownloaderService_Seeds_FullMethodName         = "/hashicorp.vagrant.sdk.DownloaderService/Seeds"
 	DownloaderService_SetPluginName_FullMethodName = "/hashicorp.vagrant.sdk.DownloaderService/SetPluginName"
 	DownloaderService_PluginName_FullMethodName    = "/hashicorp.vagrant.sdk.DownloaderService/PluginName"
+	DownloaderService_Documentation_FullMethodName = "/hashicorp.vagrant.sdk.DownloaderService/Documentation"
 )
 
 // DownloaderServiceClient is the client API for DownloaderService service.
@@ -10816,6 +11386,7 @@ type DownloaderServiceClient interface {
 	Seeds(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Args_Seeds, error)
 	SetPluginName(ctx context.Context, in *PluginInfo_Name, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	PluginName(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PluginInfo_Name, error)
+	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
 }
 
 type downloaderServiceClient struct {
@@ -10880,6 +11451,15 @@ func (c *downloaderServiceClient) PluginName(ctx context.Context, in *emptypb.Em
 	return out, nil
 }
 
+func (c *downloaderServiceClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
+	out := new(Config_Documentation)
+	err := c.cc.Invoke(ctx, DownloaderService_Documentation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DownloaderServiceServer is the server API for DownloaderService service.
 // All implementations should embed UnimplementedDownloaderServiceServer
 // for forward compatibility
@@ -10890,6 +11470,7 @@ type DownloaderServiceServer interface {
 	Seeds(context.Context, *emptypb.Empty) (*Args_Seeds, error)
 	SetPluginName(context.Context, *PluginInfo_Name) (*emptypb.Empty, error)
 	PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error)
+	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
 }
 
 // UnimplementedDownloaderServiceServer should be embedded to have forward compatible implementations.
@@ -10914,6 +11495,9 @@ func (UnimplementedDownloaderServiceServer) SetPluginName(context.Context, *Plug
 func (UnimplementedDownloaderServiceServer) PluginName(context.Context, *emptypb.Empty) (*PluginInfo_Name, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PluginName not implemented")
 }
+func (UnimplementedDownloaderServiceServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
+}
 
 // UnsafeDownloaderServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to DownloaderServiceServer will
@@ -11034,6 +11618,24 @@ func _DownloaderService_PluginName_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DownloaderService_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DownloaderServiceServer).Documentation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DownloaderService_Documentation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DownloaderServiceServer).Documentation(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DownloaderService_ServiceDesc is the grpc.ServiceDesc for DownloaderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -11065,6 +11667,10 @@ var DownloaderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PluginName",
 			Handler:    _DownloaderService_PluginName_Handler,
 		},
+		{
+			MethodName: "Documentation",
+			Handler:    _DownloaderService_Documentation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vagrant_plugin_sdk/plugin.proto",
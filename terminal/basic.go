@@ -4,7 +4,6 @@
 package terminal
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"fmt"
@@ -35,43 +34,35 @@ func BasicUI(ctx context.Context) UI {
 
 // Input implements UI
 func (ui *basicUI) Input(input *Input) (string, error) {
-	var buf bytes.Buffer
-
-	// Write the prompt, add a space.
-	ui.Output(input.Prompt, WithStyle(input.Style), WithWriter(&buf), WithColor(input.Color))
-	fmt.Fprint(color.Output, strings.TrimRight(buf.String(), "\r\n"))
-	fmt.Fprint(color.Output, " ")
-
-	// Ask for input in a go-routine so that we can ignore it.
-	errCh := make(chan error, 1)
-	lineCh := make(chan string, 1)
-	go func() {
-		var line string
-		var err error
-		if input.Secret && isatty.IsTerminal(os.Stdin.Fd()) {
-			line, err = speakeasy.Ask("")
-		} else {
-			r := bufio.NewReader(os.Stdin)
-			line, err = r.ReadString('\n')
-		}
-		if err != nil {
-			errCh <- err
-			return
-		}
-
-		lineCh <- strings.TrimRight(line, "\r\n")
-	}()
+	answer, err := askInput(ui.ctx, input, &inputPrompter{
+		prompt: func(text string) {
+			var buf bytes.Buffer
+
+			// Write the prompt, add a space.
+			ui.Output(text, WithStyle(input.Style), WithWriter(&buf), WithColor(input.Color))
+			fmt.Fprint(color.Output, strings.TrimRight(buf.String(), "\r\n"))
+			fmt.Fprint(color.Output, " ")
+			flushOutput(color.Output)
+		},
+		read: func() (string, error) {
+			if input.Secret && isatty.IsTerminal(os.Stdin.Fd()) {
+				return speakeasy.Ask("")
+			}
 
-	select {
-	case err := <-errCh:
-		return "", err
-	case line := <-lineCh:
-		return line, nil
-	case <-ui.ctx.Done():
+			line, err := stdin.ReadString('\n')
+			return strings.TrimRight(line, "\r\n"), err
+		},
+		invalid: func(err error) {
+			ui.Output(err.Error(), WithErrorStyle())
+		},
+		reader: stdinReader,
+	})
+	if err != nil {
 		// Print newline so that any further output starts properly
 		fmt.Fprintln(color.Output)
-		return "", ui.ctx.Err()
 	}
+
+	return answer, err
 }
 
 // Interactive implements UI
@@ -167,12 +158,12 @@ func (ui *basicUI) NamedValues(rows []NamedValue, opts ...Option) {
 	}
 
 	tr.Flush()
-	fmt.Fprintln(cfg.Writer, buf.String())
+	fmt.Fprintln(cfg.Writer, Redact(buf.String()))
 }
 
 // OutputWriters implements UI
 func (ui *basicUI) OutputWriters() (io.Writer, io.Writer, error) {
-	return os.Stdout, os.Stderr, nil
+	return RedactWriter(os.Stdout), RedactWriter(os.Stderr), nil
 }
 
 // Status implements UI
@@ -184,6 +175,17 @@ func (ui *basicUI) Status() Status {
 	return ui.status
 }
 
+// Progress implements UI. The progress is drawn on a single line
+// which is redrawn as it changes.
+func (ui *basicUI) Progress(msg string, total int64) Progress {
+	return newProgressTracker(msg, total, progressInterval, func(s ProgressStats, final bool) {
+		fmt.Fprint(color.Output, "\r\033[K"+s.line())
+		if final {
+			fmt.Fprintln(color.Output)
+		}
+	})
+}
+
 func (ui *basicUI) StepGroup() StepGroup {
 	ctx, cancel := context.WithCancel(ui.ctx)
 	display := NewDisplay(ctx, color.Output)
@@ -4,7 +4,6 @@
 package terminal
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"fmt"
@@ -14,6 +13,7 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	"github.com/fatih/color"
 	"github.com/mitchellh/go-glint"
 	"github.com/olekukonko/tablewriter"
 	"golang.org/x/term"
@@ -86,34 +86,52 @@ func (ui *glintUI) Close() error {
 }
 
 func (ui *glintUI) Input(input *Input) (line string, err error) {
-	ui.Output(input.Prompt, WithoutNewLine(), WithStyle(input.Style), WithColor(input.Color))
-	// Render the last frame
-	ui.d.RenderFrame()
-	// Pause so that input can be read
-	ui.d.Pause()
-	defer ui.d.Resume()
+	first := true
+	answer, err := askInput(context.Background(), input, &inputPrompter{
+		prompt: func(text string) {
+			if !first {
+				// Rendering is paused so prompt directly
+				fmt.Fprint(color.Output, text+" ")
+				flushOutput(color.Output)
+				return
+			}
+			first = false
+
+			ui.Output(text, WithoutNewLine(), WithStyle(input.Style), WithColor(input.Color))
+			// Render the last frame
+			ui.d.RenderFrame()
+			// Pause so that input can be read
+			ui.d.Pause()
+		},
+		read: func() (string, error) {
+			if input.Secret {
+				l, err := term.ReadPassword(int(os.Stdin.Fd()))
+				return string(l), err
+			}
 
-	if input.Secret {
-		l, err := term.ReadPassword(int(os.Stdin.Fd()))
-		if err != nil {
-			return "", err
-		}
-		line = string(l)
-	} else {
-		r := bufio.NewReader(os.Stdin)
-		line, err = r.ReadString('\n')
+			return stdin.ReadString('\n')
+		},
+		invalid: func(err error) {
+			if input.Secret {
+				fmt.Fprintln(color.Output)
+			}
+			fmt.Fprintln(color.Output, color.RedString(err.Error()))
+		},
+		reader: stdinReader,
+	})
+	if !first {
+		defer ui.d.Resume()
 	}
 	if err != nil {
 		return "", err
 	}
 
-	text := strings.TrimSpace(line)
 	if input.Secret {
 		ui.Output("")
 	} else {
-		ui.Output(text)
+		ui.Output(answer)
 	}
-	return text, nil
+	return answer, nil
 }
 
 // Interactive implements UI
@@ -237,7 +255,7 @@ func (ui *glintUI) NamedValues(rows []NamedValue, opts ...Option) {
 	tr.Flush()
 
 	// We want to trim the trailing newline
-	text := buf.String()
+	text := Redact(buf.String())
 	if len(text) > 0 && text[len(text)-1] == '\n' {
 		text = text[:len(text)-1]
 	}
@@ -247,7 +265,7 @@ func (ui *glintUI) NamedValues(rows []NamedValue, opts ...Option) {
 
 // OutputWriters implements UI
 func (ui *glintUI) OutputWriters() (io.Writer, io.Writer, error) {
-	return os.Stdout, os.Stderr, nil
+	return RedactWriter(os.Stdout), RedactWriter(os.Stderr), nil
 }
 
 // Status implements UI
@@ -257,6 +275,13 @@ func (ui *glintUI) Status() Status {
 	return st
 }
 
+// Progress implements UI
+func (ui *glintUI) Progress(msg string, total int64) Progress {
+	p := newGlintProgress(msg, total)
+	ui.d.Append(p)
+	return p
+}
+
 func (ui *glintUI) StepGroup() StepGroup {
 	ctx, cancel := context.WithCancel(context.Background())
 	sg := &glintStepGroup{ctx: ctx, cancel: cancel}
@@ -266,6 +291,7 @@ func (ui *glintUI) StepGroup() StepGroup {
 
 // Table implements UI
 func (ui *glintUI) Table(tbl *Table, opts ...Option) {
+	tbl = redactTable(tbl).fit(tableWidth(tbl, true), true)
 	var buf bytes.Buffer
 	table := tablewriter.NewWriter(&buf)
 	table.SetHeader(tbl.Headers)
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package terminal
+
+import (
+	"context"
+
+	"github.com/mitchellh/go-glint"
+)
+
+// glintProgress implements Progress and draws a bar with the
+// rate and ETA. It is drawn from the current state each frame
+// so updates are not rendered directly.
+type glintProgress struct {
+	*progressTracker
+}
+
+func newGlintProgress(msg string, total int64) *glintProgress {
+	return &glintProgress{
+		progressTracker: newProgressTracker(msg, total, progressInterval, nil),
+	}
+}
+
+func (p *glintProgress) Body(context.Context) glint.Component {
+	c := glint.Text(p.Stats().line())
+	if p.isClosed() {
+		return glint.Finalize(c)
+	}
+
+	return c
+}
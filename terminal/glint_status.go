@@ -29,7 +29,7 @@ func newGlintStatus() *glintStatus {
 func (s *glintStatus) Update(msg string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.msg = msg
+	s.msg = Redact(msg)
 }
 
 func (s *glintStatus) Step(status, msg string) {
@@ -49,6 +49,8 @@ func (s *glintStatus) Step(status, msg string) {
 		style = append(style, glint.Color("lightYellow"))
 	}
 
+	msg = Redact(msg)
+
 	// If we have a prefix, set that
 	if icon, ok := statusIcons[status]; ok {
 		msg = icon + " " + msg
@@ -80,6 +80,8 @@ type glintStep struct {
 	statusVal string
 	status    *glintStatus
 	term      *glintTerm
+
+	redactedTerm io.Writer
 }
 
 func (f *glintStep) TermOutput() io.Writer {
@@ -93,15 +95,16 @@ func (f *glintStep) TermOutput() io.Writer {
 		}
 
 		f.term = t
+		f.redactedTerm = RedactWriter(t)
 	}
 
-	return f.term
+	return f.redactedTerm
 }
 
 func (f *glintStep) Update(str string, args ...interface{}) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	f.msg = fmt.Sprintf(str, args...)
+	f.msg = Redact(fmt.Sprintf(str, args...))
 	f.status.reset()
 
 	if f.statusVal != "" {
@@ -3,6 +3,28 @@
 
 package terminal
 
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInputTimeout is returned when an input is not answered
+// before its timeout and it has no default
+var ErrInputTimeout = errors.New("timed out waiting for input")
+
+// Answers of a confirmation input
+const (
+	ConfirmYes = "yes"
+	ConfirmNo  = "no"
+)
+
 // Input is the configuration for an input.
 type Input struct {
 	// Prompt is a single-line prompt to give the user such as "Continue?"
@@ -19,4 +41,225 @@ type Input struct {
 	// Color is the color to apply to the input. If this is blank,
 	// the output will be the default color for the terminal.
 	Color string
+
+	// Choices the answer must be selected from. The choices are
+	// displayed as a numbered list and may be selected by number
+	// or value. The value of the choice is returned.
+	Choices []string
+
+	// True if this input asks a yes or no question. The answer is
+	// returned as ConfirmYes or ConfirmNo.
+	Confirm bool
+
+	// Default is the answer used when an empty answer is given
+	// or the input times out.
+	Default string
+
+	// Validate is called with the answer. If an error is returned
+	// the error is displayed and the user is prompted again.
+	// Validation is performed by the plugin when used over gRPC.
+	Validate func(answer string) error `json:"-"`
+
+	// Timeout is the time to wait for an answer. No timeout is
+	// applied when zero.
+	Timeout time.Duration
+}
+
+// Display returns the text displayed when prompting for the input,
+// including any choices and the default answer
+func (i *Input) Display() string {
+	var sb strings.Builder
+	for n, c := range i.Choices {
+		fmt.Fprintf(&sb, "%d) %s\n", n+1, c)
+	}
+	sb.WriteString(i.Prompt)
+
+	switch {
+	case i.Confirm && isYes(i.Default):
+		sb.WriteString(" [Y/n]")
+	case i.Confirm && isNo(i.Default):
+		sb.WriteString(" [y/N]")
+	case i.Confirm:
+		sb.WriteString(" [y/n]")
+	case i.Default != "" && !i.Secret:
+		fmt.Fprintf(&sb, " [%s]", i.Default)
+	}
+
+	return sb.String()
+}
+
+// Resolve returns the answer after applying the default, resolving
+// confirmations and choices, and validating the result. An error is
+// returned if the answer is not valid.
+func (i *Input) Resolve(answer string) (string, error) {
+	if !i.Secret {
+		answer = strings.TrimSpace(answer)
+	}
+	if answer == "" {
+		answer = i.Default
+	}
+
+	switch {
+	case i.Confirm:
+		switch {
+		case isYes(answer):
+			answer = ConfirmYes
+		case isNo(answer):
+			answer = ConfirmNo
+		default:
+			return "", fmt.Errorf("please answer yes or no")
+		}
+	case len(i.Choices) > 0:
+		choice, ok := i.choice(answer)
+		if !ok {
+			return "", fmt.Errorf("please select a number from 1 to %d", len(i.Choices))
+		}
+		answer = choice
+	}
+
+	if i.Validate != nil {
+		if err := i.Validate(answer); err != nil {
+			return "", err
+		}
+	}
+
+	return answer, nil
+}
+
+// choice returns the choice selected by the answer using either
+// the number or the value of the choice
+func (i *Input) choice(answer string) (string, bool) {
+	if n, err := strconv.Atoi(answer); err == nil && n > 0 && n <= len(i.Choices) {
+		return i.Choices[n-1], true
+	}
+	for _, c := range i.Choices {
+		if c == answer {
+			return c, true
+		}
+	}
+
+	return "", false
+}
+
+// Confirmed returns if the answer to a confirmation input is yes
+func Confirmed(answer string) bool {
+	return isYes(answer)
+}
+
+func isYes(s string) bool {
+	s = strings.ToLower(s)
+	return s == "y" || s == ConfirmYes
+}
+
+func isNo(s string) bool {
+	s = strings.ToLower(s)
+	return s == "n" || s == ConfirmNo
+}
+
+// inputPrompter is used by askInput to interact with the user
+type inputPrompter struct {
+	// prompt displays the text of the input
+	prompt func(text string)
+	// read reads an answer
+	read func() (string, error)
+	// invalid displays the reason an answer was not valid
+	invalid func(err error)
+	// reader runs the reads, it is shared by all inputs reading
+	// from the same source
+	reader *inputReader
+}
+
+// askInput prompts until a valid answer is given. If the input times
+// out the default is used if set, otherwise ErrInputTimeout is returned.
+func askInput(ctx context.Context, input *Input, p *inputPrompter) (string, error) {
+	for {
+		p.prompt(input.Display())
+
+		line, err := p.reader.read(ctx, input.Timeout, input.Secret, p.read)
+		if err == ErrInputTimeout && input.Default != "" {
+			line, err = "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		answer, err := input.Resolve(line)
+		if err == nil {
+			return answer, nil
+		}
+		p.invalid(err)
+	}
+}
+
+// stdinReader reads the answers of inputs from stdin. It is shared
+// by all UIs since stdin is shared by the process.
+var stdinReader = &inputReader{}
+
+// stdin buffers the lines read from os.Stdin
+var stdin = bufio.NewReader(os.Stdin)
+
+// inputReader reads answers in the background so reading can stop
+// early when the context is done or the timeout is reached. A read
+// which is still waiting for an answer when reading stops is used by
+// the next read, so the next answer is not lost to an abandoned read.
+// A pending read is only reused by a read of the same secrecy, so a
+// secret answer is never read with echo enabled and a visible answer
+// is never read with echo disabled. Otherwise the pending read is
+// discarded and its result is dropped.
+type inputReader struct {
+	mu      sync.Mutex
+	pending *pendingRead
+}
+
+type pendingRead struct {
+	secret bool
+	result chan inputResult
+}
+
+type inputResult struct {
+	line string
+	err  error
+}
+
+func (r *inputReader) read(
+	ctx context.Context,
+	timeout time.Duration,
+	secret bool,
+	read func() (string, error),
+) (string, error) {
+	r.mu.Lock()
+	if r.pending != nil && r.pending.secret != secret {
+		r.pending = nil
+	}
+	if r.pending == nil {
+		p := &pendingRead{secret: secret, result: make(chan inputResult, 1)}
+		go func() {
+			line, err := read()
+			p.result <- inputResult{line: line, err: err}
+		}()
+		r.pending = p
+	}
+	pending := r.pending
+	r.mu.Unlock()
+
+	var timeoutCh <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
+	select {
+	case result := <-pending.result:
+		r.mu.Lock()
+		if r.pending == pending {
+			r.pending = nil
+		}
+		r.mu.Unlock()
+		return result.line, result.err
+	case <-timeoutCh:
+		return "", ErrInputTimeout
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
 }
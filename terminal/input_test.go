@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package terminal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput_Display(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Input    *Input
+		Expected string
+	}{
+		{"prompt", &Input{Prompt: "Name?"}, "Name?"},
+		{"default", &Input{Prompt: "Name?", Default: "box"}, "Name? [box]"},
+		{"secret default", &Input{Prompt: "Password?", Default: "box", Secret: true}, "Password?"},
+		{"confirm", &Input{Prompt: "Continue?", Confirm: true}, "Continue? [y/n]"},
+		{"confirm yes", &Input{Prompt: "Continue?", Confirm: true, Default: "yes"}, "Continue? [Y/n]"},
+		{"confirm no", &Input{Prompt: "Continue?", Confirm: true, Default: "n"}, "Continue? [y/N]"},
+		{"choices", &Input{Prompt: "Interface?", Choices: []string{"eth0", "eth1"}}, "1) eth0\n2) eth1\nInterface?"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require.Equal(t, tt.Expected, tt.Input.Display())
+		})
+	}
+}
+
+func TestInput_Resolve(t *testing.T) {
+	choices := []string{"eth0", "eth1"}
+	cases := []struct {
+		Name     string
+		Input    *Input
+		Answer   string
+		Expected string
+		Err      bool
+	}{
+		{"trimmed", &Input{}, " box \n", "box", false},
+		{"secret", &Input{Secret: true}, " box ", " box ", false},
+		{"default", &Input{Default: "box"}, "", "box", false},
+		{"confirm yes", &Input{Confirm: true}, "Y", ConfirmYes, false},
+		{"confirm no", &Input{Confirm: true}, "no", ConfirmNo, false},
+		{"confirm default", &Input{Confirm: true, Default: "y"}, "", ConfirmYes, false},
+		{"confirm invalid", &Input{Confirm: true}, "maybe", "", true},
+		{"choice number", &Input{Choices: choices}, "2", "eth1", false},
+		{"choice value", &Input{Choices: choices}, "eth0", "eth0", false},
+		{"choice default", &Input{Choices: choices, Default: "eth1"}, "", "eth1", false},
+		{"choice out of range", &Input{Choices: choices}, "3", "", true},
+		{"choice invalid", &Input{Choices: choices}, "eth2", "", true},
+		{"validate", &Input{Validate: func(string) error { return errors.New("invalid") }}, "box", "", true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			result, err := tt.Input.Resolve(tt.Answer)
+			if tt.Err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.Expected, result)
+		})
+	}
+}
+
+func testPrompter(answers ...string) (*inputPrompter, *[]string, *[]error) {
+	var prompts []string
+	var invalid []error
+	return &inputPrompter{
+		prompt: func(text string) { prompts = append(prompts, text) },
+		read: func() (string, error) {
+			if len(answers) == 0 {
+				select {}
+			}
+			answer := answers[0]
+			answers = answers[1:]
+			return answer, nil
+		},
+		invalid: func(err error) { invalid = append(invalid, err) },
+		reader:  &inputReader{},
+	}, &prompts, &invalid
+}
+
+func TestAskInput_reprompt(t *testing.T) {
+	require := require.New(t)
+
+	p, prompts, invalid := testPrompter("3", "1", "eth1")
+	result, err := askInput(context.Background(), &Input{
+		Prompt:  "Interface?",
+		Choices: []string{"eth0", "eth1"},
+		Validate: func(answer string) error {
+			if answer == "eth0" {
+				return errors.New("eth0 is in use")
+			}
+			return nil
+		},
+	}, p)
+	require.NoError(err)
+	require.Equal("eth1", result)
+	require.Len(*prompts, 3)
+	require.Len(*invalid, 2)
+	require.EqualError((*invalid)[1], "eth0 is in use")
+}
+
+func TestAskInput_timeout(t *testing.T) {
+	require := require.New(t)
+
+	p, _, _ := testPrompter()
+	_, err := askInput(context.Background(), &Input{
+		Prompt:  "Continue?",
+		Timeout: time.Millisecond,
+	}, p)
+	require.Equal(ErrInputTimeout, err)
+
+	p, _, _ = testPrompter()
+	result, err := askInput(context.Background(), &Input{
+		Prompt:  "Continue?",
+		Confirm: true,
+		Default: "y",
+		Timeout: time.Millisecond,
+	}, p)
+	require.NoError(err)
+	require.Equal(ConfirmYes, result)
+}
+
+func TestInputReader(t *testing.T) {
+	require := require.New(t)
+
+	lines := make(chan string)
+	reads := 0
+	read := func() (string, error) {
+		reads++
+		return <-lines, nil
+	}
+
+	r := &inputReader{}
+	_, err := r.read(context.Background(), time.Millisecond, false, read)
+	require.Equal(ErrInputTimeout, err)
+
+	// The answer given after the timeout is read by the next input
+	go func() { lines <- "yes" }()
+	line, err := r.read(context.Background(), 0, false, read)
+	require.NoError(err)
+	require.Equal("yes", line)
+	require.Equal(1, reads)
+
+	go func() { lines <- "no" }()
+	line, err = r.read(context.Background(), 0, false, read)
+	require.NoError(err)
+	require.Equal("no", line)
+	require.Equal(2, reads)
+}
+
+func TestInputReader_secret(t *testing.T) {
+	require := require.New(t)
+
+	plain := make(chan string, 1)
+	secret := make(chan string, 1)
+	readPlain := func() (string, error) { return <-plain, nil }
+	readSecret := func() (string, error) { return <-secret, nil }
+
+	r := &inputReader{}
+	_, err := r.read(context.Background(), time.Millisecond, false, readPlain)
+	require.Equal(ErrInputTimeout, err)
+
+	// The pending plain read is not used to read a secret
+	secret <- "hunter2"
+	line, err := r.read(context.Background(), 0, true, readSecret)
+	require.NoError(err)
+	require.Equal("hunter2", line)
+
+	// The discarded plain read does not answer the next input
+	plain <- "stale"
+	_, err = r.read(context.Background(), time.Millisecond, true, readSecret)
+	require.Equal(ErrInputTimeout, err)
+
+	// The pending secret read is not used to read a visible answer
+	plain <- "visible"
+	line, err = r.read(context.Background(), 0, false, readPlain)
+	require.NoError(err)
+	require.Equal("visible", line)
+}
@@ -0,0 +1,352 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package terminal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// Types of the events output by the JSONFormat machine readable UI
+const (
+	EventOutput      = "output"
+	EventError       = "error"
+	EventNamedValues = "named_values"
+	EventTable       = "table"
+	EventStatus      = "status"
+	EventProgress    = "progress"
+	EventStep        = "step"
+	EventStepOutput  = "step_output"
+	EventStdout      = "stdout"
+	EventStderr      = "stderr"
+)
+
+// Event is a single line of JSONFormat machine readable output
+type Event struct {
+	Timestamp time.Time              `json:"timestamp"`
+	Target    string                 `json:"target,omitempty"`
+	Type      string                 `json:"type"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+type jsonUI struct {
+	mu   sync.Mutex
+	opts []Option
+	now  func() time.Time
+
+	steps int // Number of steps created, used for step ids
+}
+
+func newJSONUI(opts ...Option) *jsonUI {
+	return &jsonUI{opts: opts, now: time.Now}
+}
+
+// Input implements UI
+func (ui *jsonUI) Input(input *Input) (string, error) {
+	return "", ErrNonInteractive
+}
+
+// Interactive implements UI
+func (ui *jsonUI) Interactive() bool {
+	return false
+}
+
+// MachineReadable implements UI
+func (ui *jsonUI) MachineReadable() bool {
+	return true
+}
+
+// Output implements UI. Output using an error style is output
+// as an error event.
+func (ui *jsonUI) Output(msg string, raw ...interface{}) {
+	var args []interface{}
+	var opts []Option
+	for _, r := range raw {
+		if opt, ok := r.(Option); ok {
+			opts = append(opts, opt)
+		} else {
+			args = append(args, r)
+		}
+	}
+	cfg := ui.config(opts)
+
+	typ := EventOutput
+	if cfg.Style == ErrorStyle || cfg.Style == ErrorBoldStyle {
+		typ = EventError
+	}
+
+	data := map[string]interface{}{
+		"message": Redact(fmt.Sprintf(msg, args...)),
+	}
+	if cfg.Style != "" {
+		data["style"] = cfg.Style
+	}
+
+	ui.emit(cfg, typ, data)
+}
+
+func (ui *jsonUI) ClearLine() {
+	// NO-OP
+}
+
+// NamedValues implements UI
+func (ui *jsonUI) NamedValues(rows []NamedValue, opts ...Option) {
+	values := map[string]interface{}{}
+	for _, row := range rows {
+		if s, ok := row.Value.(string); ok {
+			values[row.Name] = Redact(s)
+			continue
+		}
+		values[row.Name] = row.Value
+	}
+
+	ui.emit(ui.config(opts), EventNamedValues, map[string]interface{}{
+		"values": values,
+	})
+}
+
+// OutputWriters implements UI. Each write is output as a
+// stdout or stderr event.
+func (ui *jsonUI) OutputWriters() (io.Writer, io.Writer, error) {
+	cfg := ui.config(nil)
+	return RedactWriter(&jsonWriter{ui: ui, cfg: cfg, typ: EventStdout}),
+		RedactWriter(&jsonWriter{ui: ui, cfg: cfg, typ: EventStderr}), nil
+}
+
+// Status implements UI
+func (ui *jsonUI) Status() Status {
+	return &jsonStatus{ui: ui, cfg: ui.config(nil)}
+}
+
+// Progress implements UI. The progress is output as an event
+// periodically and when it is closed. The rate is in bytes per
+// second and the ETA is in seconds.
+func (ui *jsonUI) Progress(msg string, total int64) Progress {
+	cfg := ui.config(nil)
+	return newProgressTracker(msg, total, machineReadableProgressInterval, func(s ProgressStats, final bool) {
+		ui.emit(cfg, EventProgress, map[string]interface{}{
+			"message": s.Message,
+			"total":   s.Total,
+			"current": s.Current,
+			"rate":    s.Rate,
+			"eta":     s.ETA.Seconds(),
+			"done":    final,
+		})
+	})
+}
+
+// StepGroup implements UI
+func (ui *jsonUI) StepGroup() StepGroup {
+	return &jsonStepGroup{ui: ui, cfg: ui.config(nil)}
+}
+
+// Table implements UI
+func (ui *jsonUI) Table(tbl *Table, opts ...Option) {
+	tbl = redactTable(tbl).fit(tableWidth(tbl, false), false)
+
+	rows := make([][]string, len(tbl.Rows))
+	for i, row := range tbl.Rows {
+		rows[i] = make([]string, len(row))
+		for j, ent := range row {
+			rows[i][j] = ent.Value
+		}
+	}
+
+	ui.emit(ui.config(opts), EventTable, map[string]interface{}{
+		"headers": tbl.Headers,
+		"rows":    rows,
+	})
+}
+
+// config builds the configuration using the options of the
+// UI followed by the given options
+func (ui *jsonUI) config(opts []Option) *config {
+	cfg := &config{}
+	for _, opt := range ui.opts {
+		opt(cfg)
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.Writer == nil {
+		cfg.Writer = color.Output
+	}
+
+	return cfg
+}
+
+// emit writes the event as a single line of JSON
+func (ui *jsonUI) emit(cfg *config, typ string, data map[string]interface{}) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.write(cfg, typ, data)
+}
+
+// write writes the event. The lock must be held when called.
+func (ui *jsonUI) write(cfg *config, typ string, data map[string]interface{}) {
+	json.NewEncoder(cfg.Writer).Encode(&Event{
+		Timestamp: ui.now().UTC(),
+		Target:    cfg.Target,
+		Type:      typ,
+		Data:      data,
+	})
+}
+
+type jsonWriter struct {
+	ui  *jsonUI
+	cfg *config
+	typ string
+}
+
+func (w *jsonWriter) Write(p []byte) (int, error) {
+	w.ui.emit(w.cfg, w.typ, map[string]interface{}{
+		"data": string(p),
+	})
+
+	return len(p), nil
+}
+
+type jsonStatus struct {
+	ui  *jsonUI
+	cfg *config
+}
+
+func (s *jsonStatus) Update(msg string) {
+	s.ui.emit(s.cfg, EventStatus, map[string]interface{}{
+		"message": Redact(msg),
+	})
+}
+
+func (s *jsonStatus) Step(status, msg string) {
+	s.ui.emit(s.cfg, EventStatus, map[string]interface{}{
+		"status":  status,
+		"message": Redact(msg),
+	})
+}
+
+func (s *jsonStatus) Close() error {
+	return nil
+}
+
+type jsonStepGroup struct {
+	ui     *jsonUI
+	cfg    *config
+	wg     sync.WaitGroup
+	closed bool
+}
+
+// Add implements StepGroup. Each step is identified within its
+// events by a number unique to the UI.
+func (g *jsonStepGroup) Add(str string, args ...interface{}) Step {
+	g.ui.mu.Lock()
+	defer g.ui.mu.Unlock()
+
+	g.ui.steps++
+	step := &jsonStep{ui: g.ui, cfg: g.cfg, id: g.ui.steps}
+	g.ui.write(g.cfg, EventStep, map[string]interface{}{
+		"id":      step.id,
+		"message": Redact(fmt.Sprintf(str, args...)),
+	})
+
+	// If we're closed we don't add this step to our waitgroup.
+	// We still return a non-nil step so downstreams don't crash.
+	if !g.closed {
+		step.wg = &g.wg
+		g.wg.Add(1)
+	}
+
+	return step
+}
+
+func (g *jsonStepGroup) Wait() {
+	g.ui.mu.Lock()
+	g.closed = true
+	wg := &g.wg
+	g.ui.mu.Unlock()
+
+	wg.Wait()
+}
+
+type jsonStep struct {
+	ui   *jsonUI
+	cfg  *config
+	id   int
+	wg   *sync.WaitGroup
+	done bool
+	out  io.Writer
+}
+
+func (s *jsonStep) TermOutput() io.Writer {
+	s.ui.mu.Lock()
+	defer s.ui.mu.Unlock()
+
+	if s.out == nil {
+		s.out = RedactWriter(&stripAnsiWriter{Next: &jsonStepWriter{step: s}})
+	}
+
+	return s.out
+}
+
+func (s *jsonStep) Update(str string, args ...interface{}) {
+	s.ui.emit(s.cfg, EventStep, map[string]interface{}{
+		"id":      s.id,
+		"message": Redact(fmt.Sprintf(str, args...)),
+	})
+}
+
+func (s *jsonStep) Status(status string) {
+	s.ui.emit(s.cfg, EventStep, map[string]interface{}{
+		"id":     s.id,
+		"status": status,
+	})
+}
+
+func (s *jsonStep) Done() {
+	s.ui.mu.Lock()
+	defer s.ui.mu.Unlock()
+
+	if s.done {
+		return
+	}
+	s.done = true
+	s.ui.write(s.cfg, EventStep, map[string]interface{}{
+		"id":   s.id,
+		"done": true,
+	})
+
+	if s.wg != nil {
+		s.wg.Done()
+	}
+}
+
+func (s *jsonStep) Abort() {
+	s.ui.mu.Lock()
+	done := s.done
+	s.ui.mu.Unlock()
+	if done {
+		return
+	}
+
+	s.Status(StatusError)
+	s.Done()
+}
+
+type jsonStepWriter struct {
+	step *jsonStep
+}
+
+func (w *jsonStepWriter) Write(p []byte) (int, error) {
+	w.step.ui.emit(w.step.cfg, EventStepOutput, map[string]interface{}{
+		"id":     w.step.id,
+		"output": string(p),
+	})
+
+	return len(p), nil
+}
@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package terminal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testJSONUI(buf *bytes.Buffer, opts ...Option) *jsonUI {
+	ui := MachineReadableUI(context.Background(), JSONFormat,
+		append([]Option{WithWriter(buf)}, opts...)...).(*jsonUI)
+	ui.now = func() time.Time {
+		return time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	}
+
+	return ui
+}
+
+func jsonEvents(t *testing.T, buf *bytes.Buffer) []*Event {
+	var events []*Event
+	s := bufio.NewScanner(buf)
+	for s.Scan() {
+		var e Event
+		require.NoError(t, json.Unmarshal(s.Bytes(), &e))
+		events = append(events, &e)
+	}
+
+	return events
+}
+
+func TestJSONUI(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	ui := testJSONUI(&buf, WithTarget("default"))
+	require.True(ui.MachineReadable())
+	require.False(ui.Interactive())
+
+	ui.Output("hello %s", "world", WithInfoStyle())
+	ui.Output("failed", WithErrorStyle(), WithTarget("other"))
+	ui.NamedValues([]NamedValue{{"name", "test"}, {"count", 2}})
+	tbl := NewTable("A", "B")
+	tbl.Rich([]string{"1", "2"}, nil)
+	ui.Table(tbl)
+
+	s := ui.Status()
+	s.Update("working")
+	s.Step(StatusOK, "worked")
+	require.NoError(s.Close())
+
+	events := jsonEvents(t, &buf)
+	require.Len(events, 6)
+
+	require.Equal(&Event{
+		Timestamp: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		Target:    "default",
+		Type:      EventOutput,
+		Data:      map[string]interface{}{"message": "hello world", "style": InfoStyle},
+	}, events[0])
+
+	require.Equal(EventError, events[1].Type)
+	require.Equal("other", events[1].Target)
+
+	require.Equal(EventNamedValues, events[2].Type)
+	require.Equal(map[string]interface{}{"name": "test", "count": float64(2)}, events[2].Data["values"])
+
+	require.Equal(EventTable, events[3].Type)
+	require.Equal([]interface{}{"A", "B"}, events[3].Data["headers"])
+	require.Equal([]interface{}{[]interface{}{"1", "2"}}, events[3].Data["rows"])
+
+	require.Equal(EventStatus, events[4].Type)
+	require.Equal("working", events[4].Data["message"])
+	require.Equal(StatusOK, events[5].Data["status"])
+}
+
+func TestJSONUI_stepGroup(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	ui := testJSONUI(&buf)
+
+	sg := ui.StepGroup()
+	step := sg.Add("step %d", 1)
+	step.Update("updated")
+	fmt.Fprint(step.TermOutput(), "\x1b[31mred\x1b[0m")
+	step.Status(StatusWarn)
+	step.Done()
+	sg.Add("step 2").Abort()
+	sg.Wait()
+
+	events := jsonEvents(t, &buf)
+	require.Len(events, 8)
+
+	var types []string
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	require.Equal([]string{
+		EventStep, EventStep, EventStepOutput, EventStep, EventStep,
+		EventStep, EventStep, EventStep,
+	}, types)
+
+	require.Equal(float64(1), events[0].Data["id"])
+	require.Equal("step 1", events[0].Data["message"])
+	require.Equal("red", events[2].Data["output"])
+	require.Equal(true, events[4].Data["done"])
+	require.Equal(float64(2), events[5].Data["id"])
+	require.Equal(StatusError, events[6].Data["status"])
+}
+
+func TestJSONUI_redact(t *testing.T) {
+	require := require.New(t)
+	AddSensitive("hunter2")
+	defer ClearSensitive()
+
+	var buf bytes.Buffer
+	ui := testJSONUI(&buf)
+	ui.Output("password: hunter2")
+	stdout, _, err := ui.OutputWriters()
+	require.NoError(err)
+	fmt.Fprintln(stdout, "secret hunter2")
+
+	events := jsonEvents(t, &buf)
+	require.Len(events, 2)
+	require.Equal("password: "+RedactedValue, events[0].Data["message"])
+	require.Equal(EventStdout, events[1].Type)
+	require.Equal("secret "+RedactedValue+"\n", events[1].Data["data"])
+}
+
+func TestJSONUI_progress(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	ui := testJSONUI(&buf)
+
+	p := ui.Progress("Downloading", 100)
+	p.Update("Downloading box")
+	p.SetCurrent(100)
+	require.NoError(p.Close())
+
+	events := jsonEvents(t, &buf)
+	require.Len(events, 3)
+	for _, e := range events {
+		require.Equal(EventProgress, e.Type)
+	}
+	require.Equal("Downloading", events[0].Data["message"])
+	require.Equal(float64(100), events[0].Data["total"])
+	require.Equal(false, events[1].Data["done"])
+	require.Equal("Downloading box", events[2].Data["message"])
+	require.Equal(float64(100), events[2].Data["current"])
+	require.Equal(true, events[2].Data["done"])
+}
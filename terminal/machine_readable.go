@@ -23,7 +23,10 @@ import (
 type MachineReadableFormat int64
 
 const (
+	// TableFormat is the legacy Vagrant comma separated format
 	TableFormat MachineReadableFormat = iota
+	// JSONFormat outputs a JSON object for each event, one per line
+	JSONFormat
 )
 
 type machineReadableUI struct {
@@ -31,7 +34,14 @@ type machineReadableUI struct {
 	format MachineReadableFormat
 }
 
-func MachineReadableUI(ctx context.Context, format MachineReadableFormat) UI {
+// MachineReadableUI returns a UI which outputs using the format. The
+// options are applied to all output, before any options given to
+// individual calls. Options are currently only used by JSONFormat.
+func MachineReadableUI(ctx context.Context, format MachineReadableFormat, opts ...Option) UI {
+	if format == JSONFormat {
+		return newJSONUI(opts...)
+	}
+
 	result := &machineReadableUI{
 		format: format,
 	}
@@ -56,21 +66,30 @@ func (ui *machineReadableUI) MachineReadable() bool {
 // Output implements UI
 func (ui *machineReadableUI) Output(msg string, raw ...interface{}) {
 	if ui.format == TableFormat {
-		msg = strings.Replace(msg, "\n", "\\n", -1)
-		msg = strings.Replace(msg, "\r", "\\r", -1)
-		msg = strings.Replace(msg, ",", "%!(VAGRANT_COMMA)", -1)
-		tbl := NewTable()
-		trow := []TableEntry{
-			{Value: strconv.FormatInt(time.Now().Unix(), 10)},
-			{Value: "ui"},
-			{Value: "output"},
-			{Value: msg},
-		}
-		tbl.Rows = append(tbl.Rows, trow)
-		ui.Table(tbl)
+		ui.row("output", msg)
 	}
 }
 
+// row outputs a single row of the given type in the legacy
+// Vagrant format
+func (ui *machineReadableUI) row(typ, msg string) {
+	// Sensitive values are redacted before escaping since
+	// escaping may change the value
+	msg = Redact(msg)
+	msg = strings.Replace(msg, "\n", "\\n", -1)
+	msg = strings.Replace(msg, "\r", "\\r", -1)
+	msg = strings.Replace(msg, ",", "%!(VAGRANT_COMMA)", -1)
+	tbl := NewTable()
+	trow := []TableEntry{
+		{Value: strconv.FormatInt(time.Now().Unix(), 10)},
+		{Value: "ui"},
+		{Value: typ},
+		{Value: msg},
+	}
+	tbl.Rows = append(tbl.Rows, trow)
+	ui.Table(tbl)
+}
+
 func (ui *machineReadableUI) ClearLine() {
 	// NO-OP
 }
@@ -106,25 +125,36 @@ func (ui *machineReadableUI) NamedValues(rows []NamedValue, opts ...Option) {
 	}
 	tr.Flush()
 
-	fmt.Fprintln(cfg.Writer, buf.String())
+	fmt.Fprintln(cfg.Writer, Redact(buf.String()))
 }
 
 // OutputWriters implements UI
 func (ui *machineReadableUI) OutputWriters() (io.Writer, io.Writer, error) {
-	return os.Stdout, os.Stderr, nil
+	return RedactWriter(os.Stdout), RedactWriter(os.Stderr), nil
 }
 
 // Status implements UI
 func (ui *machineReadableUI) Status() Status {
-	return &nonInteractiveStatus{mu: &ui.mu}
+	return &nonInteractiveStatus{mu: &ui.mu, out: color.Output}
+}
+
+// Progress implements UI. The progress is output as a row
+// periodically and when it is closed.
+func (ui *machineReadableUI) Progress(msg string, total int64) Progress {
+	return newProgressTracker(msg, total, machineReadableProgressInterval, func(s ProgressStats, _ bool) {
+		if ui.format == TableFormat {
+			ui.row("progress", s.String())
+		}
+	})
 }
 
 func (ui *machineReadableUI) StepGroup() StepGroup {
-	return &nonInteractiveStepGroup{mu: &ui.mu}
+	return &nonInteractiveStepGroup{mu: &ui.mu, out: color.Output}
 }
 
 // Table implements UI
 func (ui *machineReadableUI) Table(tbl *Table, opts ...Option) {
+	tbl = redactTable(tbl).fit(tableWidth(tbl, false), false)
 	ui.mu.Lock()
 	defer ui.mu.Unlock()
 
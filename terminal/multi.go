@@ -0,0 +1,307 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package terminal
+
+import (
+	"io"
+	"sync"
+)
+
+// MultiUI returns a UI which broadcasts every call to all of the
+// UIs, like a console UI and a NonInteractiveWriterUI keeping a log
+// of the output. Input is only asked of the first interactive UI.
+// The prompt and answer are output to the other UIs, with the
+// answer redacted when the input is secret.
+func MultiUI(uis ...UI) UI {
+	return &multiUI{uis: uis}
+}
+
+type multiUI struct {
+	uis []UI
+}
+
+// Close closes each of the UIs which implement io.Closer. The
+// first error encountered is returned.
+func (ui *multiUI) Close() error {
+	var result error
+	for _, u := range ui.uis {
+		if c, ok := u.(io.Closer); ok {
+			if err := c.Close(); err != nil && result == nil {
+				result = err
+			}
+		}
+	}
+
+	return result
+}
+
+// Input implements UI
+func (ui *multiUI) Input(input *Input) (string, error) {
+	for i, u := range ui.uis {
+		if !u.Interactive() {
+			continue
+		}
+
+		answer, err := u.Input(input)
+		if err != nil {
+			return "", err
+		}
+
+		shown := answer
+		if input.Secret {
+			shown = RedactedValue
+		}
+		for j, other := range ui.uis {
+			if j != i {
+				other.Output("%s %s", input.Display(), shown)
+			}
+		}
+
+		return answer, nil
+	}
+
+	return "", ErrNonInteractive
+}
+
+// Interactive implements UI. The UI is interactive when
+// any of the UIs are interactive.
+func (ui *multiUI) Interactive() bool {
+	for _, u := range ui.uis {
+		if u.Interactive() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MachineReadable implements UI. The UI is machine readable
+// when all of the UIs are machine readable.
+func (ui *multiUI) MachineReadable() bool {
+	for _, u := range ui.uis {
+		if !u.MachineReadable() {
+			return false
+		}
+	}
+
+	return len(ui.uis) > 0
+}
+
+// Output implements UI
+func (ui *multiUI) Output(msg string, raw ...interface{}) {
+	for _, u := range ui.uis {
+		u.Output(msg, raw...)
+	}
+}
+
+// ClearLine implements UI
+func (ui *multiUI) ClearLine() {
+	for _, u := range ui.uis {
+		u.ClearLine()
+	}
+}
+
+// NamedValues implements UI
+func (ui *multiUI) NamedValues(rows []NamedValue, opts ...Option) {
+	for _, u := range ui.uis {
+		u.NamedValues(rows, opts...)
+	}
+}
+
+// OutputWriters implements UI. Each write is written to the
+// writers of all the UIs.
+func (ui *multiUI) OutputWriters() (io.Writer, io.Writer, error) {
+	var stdouts, stderrs []io.Writer
+	for _, u := range ui.uis {
+		stdout, stderr, err := u.OutputWriters()
+		if err != nil {
+			return nil, nil, err
+		}
+		stdouts = append(stdouts, stdout)
+		stderrs = append(stderrs, stderr)
+	}
+
+	return io.MultiWriter(stdouts...), io.MultiWriter(stderrs...), nil
+}
+
+// Status implements UI
+func (ui *multiUI) Status() Status {
+	var result multiStatus
+	for _, u := range ui.uis {
+		result = append(result, u.Status())
+	}
+
+	return result
+}
+
+// Progress implements UI
+func (ui *multiUI) Progress(msg string, total int64) Progress {
+	var result multiProgress
+	for _, u := range ui.uis {
+		result = append(result, u.Progress(msg, total))
+	}
+
+	return result
+}
+
+// StepGroup implements UI
+func (ui *multiUI) StepGroup() StepGroup {
+	var result multiStepGroup
+	for _, u := range ui.uis {
+		result = append(result, u.StepGroup())
+	}
+
+	return result
+}
+
+// Table implements UI
+func (ui *multiUI) Table(tbl *Table, opts ...Option) {
+	for _, u := range ui.uis {
+		u.Table(tbl, opts...)
+	}
+}
+
+type multiStatus []Status
+
+func (s multiStatus) Update(msg string) {
+	for _, st := range s {
+		st.Update(msg)
+	}
+}
+
+func (s multiStatus) Step(status, msg string) {
+	for _, st := range s {
+		st.Step(status, msg)
+	}
+}
+
+func (s multiStatus) Close() error {
+	var result error
+	for _, st := range s {
+		if err := st.Close(); err != nil && result == nil {
+			result = err
+		}
+	}
+
+	return result
+}
+
+type multiProgress []Progress
+
+func (p multiProgress) Write(b []byte) (int, error) {
+	for _, pr := range p {
+		if _, err := pr.Write(b); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(b), nil
+}
+
+func (p multiProgress) Update(msg string) {
+	for _, pr := range p {
+		pr.Update(msg)
+	}
+}
+
+func (p multiProgress) SetTotal(total int64) {
+	for _, pr := range p {
+		pr.SetTotal(total)
+	}
+}
+
+func (p multiProgress) SetCurrent(current int64) {
+	for _, pr := range p {
+		pr.SetCurrent(current)
+	}
+}
+
+func (p multiProgress) Add(n int64) {
+	for _, pr := range p {
+		pr.Add(n)
+	}
+}
+
+func (p multiProgress) Close() error {
+	var result error
+	for _, pr := range p {
+		if err := pr.Close(); err != nil && result == nil {
+			result = err
+		}
+	}
+
+	return result
+}
+
+type multiStepGroup []StepGroup
+
+func (g multiStepGroup) Add(str string, args ...interface{}) Step {
+	var result multiStep
+	for _, sg := range g {
+		result.steps = append(result.steps, sg.Add(str, args...))
+	}
+
+	return &result
+}
+
+func (g multiStepGroup) Wait() {
+	for _, sg := range g {
+		sg.Wait()
+	}
+}
+
+type multiStep struct {
+	steps []Step
+
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func (s *multiStep) TermOutput() io.Writer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.out == nil {
+		var ws []io.Writer
+		for _, st := range s.steps {
+			ws = append(ws, st.TermOutput())
+		}
+		s.out = io.MultiWriter(ws...)
+	}
+
+	return s.out
+}
+
+func (s *multiStep) Update(str string, args ...interface{}) {
+	for _, st := range s.steps {
+		st.Update(str, args...)
+	}
+}
+
+func (s *multiStep) Status(status string) {
+	for _, st := range s.steps {
+		st.Status(status)
+	}
+}
+
+func (s *multiStep) Done() {
+	for _, st := range s.steps {
+		st.Done()
+	}
+}
+
+func (s *multiStep) Abort() {
+	for _, st := range s.steps {
+		st.Abort()
+	}
+}
+
+var (
+	_ UI        = (*multiUI)(nil)
+	_ io.Closer = (*multiUI)(nil)
+	_ Status    = multiStatus(nil)
+	_ Progress  = multiProgress(nil)
+	_ StepGroup = multiStepGroup(nil)
+	_ Step      = (*multiStep)(nil)
+)
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package terminal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiUI(t *testing.T) {
+	require := require.New(t)
+
+	console := NewRecordingUI()
+	console.Answer("hunter2", "yes")
+	log := NewRecordingUI()
+	ui := MultiUI(console, log)
+
+	ui.Output("hello %s", "world", WithInfoStyle())
+	password, err := ui.Input(&Input{Prompt: "Password?", Secret: true})
+	require.NoError(err)
+	require.Equal("hunter2", password)
+	answer, err := ui.Input(&Input{Prompt: "Continue?", Confirm: true})
+	require.NoError(err)
+	require.Equal(ConfirmYes, answer)
+
+	sg := ui.StepGroup()
+	step := sg.Add("step")
+	fmt.Fprint(step.TermOutput(), "output")
+	step.Done()
+	sg.Wait()
+
+	require.Len(console.Events(), 7)
+	require.Len(log.Events(), 7)
+	require.Equal(EventInput, console.Events()[1].Type)
+	require.Equal("Password? "+RedactedValue, log.Events()[1].Message)
+	require.Equal("Continue? [y/n] yes", log.Events()[2].Message)
+	require.Equal("output", log.Events()[4].Data)
+}
+
+func TestMultiUI_nonInteractive(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	ui := MultiUI(NonInteractiveWriterUI(context.Background(), &buf))
+	require.False(ui.Interactive())
+	require.False(ui.MachineReadable())
+
+	_, err := ui.Input(&Input{Prompt: "Name?"})
+	require.Equal(ErrNonInteractive, err)
+}
+
+func TestNonInteractiveWriterUI(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	ui := NonInteractiveWriterUI(context.Background(), &buf)
+	ui.Output("Starting", WithHeaderStyle())
+
+	s := ui.Status()
+	s.Step(StatusOK, "started")
+	require.NoError(s.Close())
+
+	sg := ui.StepGroup()
+	step := sg.Add("step")
+	fmt.Fprint(step.TermOutput(), "\x1b[31mred\x1b[0m\n")
+	step.Done()
+	sg.Wait()
+
+	stdout, _, err := ui.OutputWriters()
+	require.NoError(err)
+	fmt.Fprint(stdout, "\x1b[1mbold\x1b[0m\n")
+
+	require.Equal("\n» Starting\n +: started\n-> step\nred\nbold\n", buf.String())
+}
@@ -19,7 +19,8 @@ import (
 )
 
 type nonInteractiveUI struct {
-	mu sync.Mutex
+	mu  sync.Mutex
+	out io.Writer // Writer for all output, color.Output when nil
 }
 
 func NonInteractiveUI(ctx context.Context) UI {
@@ -27,6 +28,22 @@ func NonInteractiveUI(ctx context.Context) UI {
 	return result
 }
 
+// NonInteractiveWriterUI returns a non-interactive UI which writes all
+// output to w with ANSI escape sequences removed. This is useful for
+// keeping a log of the output, like with MultiUI.
+func NonInteractiveWriterUI(ctx context.Context, w io.Writer) UI {
+	return &nonInteractiveUI{out: &stripAnsiWriter{Next: w}}
+}
+
+// writer returns the writer for output
+func (ui *nonInteractiveUI) writer() io.Writer {
+	if ui.out != nil {
+		return ui.out
+	}
+
+	return color.Output
+}
+
 func (ui *nonInteractiveUI) Input(input *Input) (string, error) {
 	return "", ErrNonInteractive
 }
@@ -46,6 +63,10 @@ func (ui *nonInteractiveUI) Output(msg string, raw ...interface{}) {
 	ui.mu.Lock()
 	defer ui.mu.Unlock()
 	msg, style, disableNewline, w, _ := Interpret(msg, raw...)
+	if ui.out != nil && w == color.Output {
+		// Output not given a writer is written to the writer of the UI
+		w = ui.out
+	}
 
 	var printer *color.Color
 	switch style {
@@ -93,7 +114,7 @@ func (ui *nonInteractiveUI) NamedValues(rows []NamedValue, opts ...Option) {
 	ui.mu.Lock()
 	defer ui.mu.Unlock()
 
-	cfg := &config{Writer: color.Output}
+	cfg := &config{Writer: ui.writer()}
 	for _, opt := range opts {
 		opt(cfg)
 	}
@@ -119,30 +140,46 @@ func (ui *nonInteractiveUI) NamedValues(rows []NamedValue, opts ...Option) {
 	}
 	tr.Flush()
 
-	fmt.Fprintln(cfg.Writer, buf.String())
+	fmt.Fprintln(cfg.Writer, Redact(buf.String()))
 }
 
 // OutputWriters implements UI
 func (ui *nonInteractiveUI) OutputWriters() (io.Writer, io.Writer, error) {
-	return os.Stdout, os.Stderr, nil
+	if ui.out != nil {
+		return RedactWriter(ui.out), RedactWriter(ui.out), nil
+	}
+
+	return RedactWriter(os.Stdout), RedactWriter(os.Stderr), nil
 }
 
 // Status implements UI
 func (ui *nonInteractiveUI) Status() Status {
-	return &nonInteractiveStatus{mu: &ui.mu}
+	return &nonInteractiveStatus{mu: &ui.mu, out: ui.writer()}
+}
+
+// Progress implements UI. The progress is output as a line
+// periodically and when it is closed.
+func (ui *nonInteractiveUI) Progress(msg string, total int64) Progress {
+	return newProgressTracker(msg, total, nonInteractiveProgressInterval, func(s ProgressStats, _ bool) {
+		ui.mu.Lock()
+		defer ui.mu.Unlock()
+		fmt.Fprintln(ui.writer(), s.String())
+	})
 }
 
 func (ui *nonInteractiveUI) StepGroup() StepGroup {
-	return &nonInteractiveStepGroup{mu: &ui.mu}
+	return &nonInteractiveStepGroup{mu: &ui.mu, out: ui.writer()}
 }
 
 // Table implements UI
 func (ui *nonInteractiveUI) Table(tbl *Table, opts ...Option) {
+	// Only use the width of the terminal when writing to stdout
+	tbl = redactTable(tbl).fit(tableWidth(tbl, ui.out == nil), true)
 	ui.mu.Lock()
 	defer ui.mu.Unlock()
 
 	// Build our config and set our options
-	cfg := &config{Writer: color.Output}
+	cfg := &config{Writer: ui.writer()}
 	for _, opt := range opts {
 		opt(cfg)
 	}
@@ -172,19 +209,20 @@ func (ui *nonInteractiveUI) Table(tbl *Table, opts ...Option) {
 }
 
 type nonInteractiveStatus struct {
-	mu *sync.Mutex
+	mu  *sync.Mutex
+	out io.Writer
 }
 
 func (s *nonInteractiveStatus) Update(msg string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	fmt.Fprintln(color.Output, msg)
+	fmt.Fprintln(s.out, Redact(msg))
 }
 
 func (s *nonInteractiveStatus) Step(status, msg string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	fmt.Fprintf(color.Output, "%s: %s\n", textStatus[status], msg)
+	fmt.Fprintf(s.out, "%s: %s\n", textStatus[status], Redact(msg))
 }
 
 func (s *nonInteractiveStatus) Close() error {
@@ -193,6 +231,7 @@ func (s *nonInteractiveStatus) Close() error {
 
 type nonInteractiveStepGroup struct {
 	mu     *sync.Mutex
+	out    io.Writer
 	wg     sync.WaitGroup
 	closed bool
 }
@@ -200,7 +239,7 @@ type nonInteractiveStepGroup struct {
 // Start a step in the output
 func (f *nonInteractiveStepGroup) Add(str string, args ...interface{}) Step {
 	// Build our step
-	step := &nonInteractiveStep{mu: f.mu}
+	step := &nonInteractiveStep{mu: f.mu, w: f.out}
 
 	// Setup initial status
 	step.Update(str, args...)
@@ -234,16 +273,25 @@ type nonInteractiveStep struct {
 	mu   *sync.Mutex
 	wg   *sync.WaitGroup
 	done bool
+	w    io.Writer // Writer of the UI
+	out  io.Writer
 }
 
 func (f *nonInteractiveStep) TermOutput() io.Writer {
-	return &stripAnsiWriter{Next: color.Output}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.out == nil {
+		f.out = RedactWriter(&stripAnsiWriter{Next: f.w})
+	}
+
+	return f.out
 }
 
 func (f *nonInteractiveStep) Update(str string, args ...interface{}) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	fmt.Fprintln(color.Output, "-> "+fmt.Sprintf(str, args...))
+	fmt.Fprintln(f.w, "-> "+Redact(fmt.Sprintf(str, args...)))
 }
 
 func (f *nonInteractiveStep) Status(status string) {}
@@ -271,8 +319,14 @@ type stripAnsiWriter struct {
 	Next io.Writer
 }
 
+// Write implements io.Writer. The length of p is returned on success
+// since the escape sequences removed were handled.
 func (w *stripAnsiWriter) Write(p []byte) (n int, err error) {
-	return w.Next.Write(reAnsi.ReplaceAll(p, []byte{}))
+	if _, err := w.Next.Write(reAnsi.ReplaceAll(p, []byte{})); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
 }
 
 var reAnsi = regexp.MustCompile("[\u001B\u009B][[\\]()#;?]*(?:(?:(?:[a-zA-Z\\d]*(?:;[a-zA-Z\\d]*)*)?\u0007)|(?:(?:\\d{1,4}(?:;\\d{0,4})*)?[\\dA-PRZcf-ntqry=><~]))")
@@ -0,0 +1,312 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package terminal
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Minimum time between renders of a progress for each kind of UI.
+// The progress is always rendered when the message or total changes,
+// and when it is closed.
+const (
+	progressInterval                = 100 * time.Millisecond
+	nonInteractiveProgressInterval  = 5 * time.Second
+	machineReadableProgressInterval = time.Second
+)
+
+// Width of the bar drawn by interactive UIs
+const progressBarWidth = 30
+
+// Progress is used to display the progress of an operation measured
+// in bytes, such as a box download or a disk conversion. The rate
+// and estimated time remaining are calculated from the updates.
+type Progress interface {
+	// Write adds the length of p to the current progress. This allows
+	// a Progress to be used with io.Copy, io.TeeReader, etc.
+	io.Writer
+
+	// Update changes the message displayed with the progress. This
+	// should be a single line.
+	Update(msg string)
+
+	// SetTotal sets the total number of bytes expected. A total of
+	// zero means the total is unknown.
+	SetTotal(total int64)
+
+	// SetCurrent sets the number of bytes completed.
+	SetCurrent(current int64)
+
+	// Add adds n to the number of bytes completed.
+	Add(n int64)
+
+	// Close should be called when the operation is complete.
+	Close() error
+}
+
+// ProgressStats is a snapshot of the state of a Progress
+type ProgressStats struct {
+	Message string
+	Total   int64
+	Current int64
+
+	// Rate is the average number of bytes completed per second
+	Rate float64
+
+	// ETA is the estimated time remaining. It is zero when unknown.
+	ETA time.Duration
+}
+
+// Percent returns the percentage completed, or -1 when the
+// total is unknown
+func (s ProgressStats) Percent() int {
+	if s.Total <= 0 {
+		return -1
+	}
+	if s.Current >= s.Total {
+		return 100
+	}
+
+	return int(s.Current * 100 / s.Total)
+}
+
+// Details returns the amount completed, rate, and ETA as text
+func (s ProgressStats) Details() string {
+	parts := []string{formatBytes(s.Current)}
+	if s.Total > 0 {
+		parts[0] = fmt.Sprintf("%s / %s (%d%%)",
+			formatBytes(s.Current), formatBytes(s.Total), s.Percent())
+	}
+	if s.Rate > 0 {
+		parts = append(parts, formatBytes(int64(s.Rate))+"/s")
+	}
+	if s.ETA > 0 {
+		parts = append(parts, "ETA "+s.ETA.String())
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// String returns the message followed by the details
+func (s ProgressStats) String() string {
+	if s.Message == "" {
+		return s.Details()
+	}
+
+	return s.Message + ": " + s.Details()
+}
+
+// bar returns a bar of the given width filled to the
+// percentage completed
+func (s ProgressStats) bar(width int) string {
+	pct := s.Percent()
+	if pct < 0 {
+		return "[" + strings.Repeat("?", width) + "]"
+	}
+
+	filled := width * pct / 100
+	return "[" + strings.Repeat("=", filled) + strings.Repeat(" ", width-filled) + "]"
+}
+
+// line returns the message, bar, and details as a single line
+func (s ProgressStats) line() string {
+	line := s.bar(progressBarWidth) + " " + s.Details()
+	if s.Message != "" {
+		line = s.Message + " " + line
+	}
+
+	return line
+}
+
+// formatBytes returns the number of bytes using binary units
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// progressTracker implements Progress by tracking the state of the
+// progress and calling render when it changes. Renders caused by a
+// change of the amount completed are limited to one per interval.
+type progressTracker struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	interval time.Duration
+	render   func(stats ProgressStats, final bool)
+
+	msg     string
+	total   int64
+	current int64
+	initial int64
+	started bool
+	start   time.Time
+	last    time.Time
+	closed  bool
+}
+
+// NewProgress returns a Progress which calls render with the state of
+// the progress when it changes. Changes to the bytes completed are
+// rendered at most once per interval. The progress is rendered when
+// created, and render is called with final set when it is closed.
+// This is used to implement Progress for a UI.
+func NewProgress(
+	msg string,
+	total int64,
+	interval time.Duration,
+	render func(stats ProgressStats, final bool),
+) Progress {
+	return newProgressTracker(msg, total, interval, render)
+}
+
+func newProgressTracker(
+	msg string,
+	total int64,
+	interval time.Duration,
+	render func(stats ProgressStats, final bool),
+) *progressTracker {
+	p := &progressTracker{
+		now:      time.Now,
+		interval: interval,
+		render:   render,
+		msg:      Redact(msg),
+		total:    total,
+	}
+	p.start = p.now()
+
+	// Render the initial state so the progress is displayed
+	// before any bytes are completed
+	if render != nil {
+		p.last = p.start
+		render(p.stats(), false)
+	}
+
+	return p
+}
+
+func (p *progressTracker) Write(b []byte) (int, error) {
+	p.Add(int64(len(b)))
+	return len(b), nil
+}
+
+func (p *progressTracker) Update(msg string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.msg = Redact(msg)
+	p.changed(true)
+}
+
+func (p *progressTracker) SetTotal(total int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.total = total
+	p.changed(true)
+}
+
+func (p *progressTracker) SetCurrent(current int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// The first progress set is treated as the starting point, like
+	// when resuming a download, so it is not included in the rate
+	if !p.started {
+		p.initial = current
+	}
+	p.started = true
+	p.current = current
+	p.changed(false)
+}
+
+func (p *progressTracker) Add(n int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.started = true
+	p.current += n
+	p.changed(false)
+}
+
+func (p *progressTracker) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+
+	if p.render != nil {
+		p.render(p.stats(), true)
+	}
+
+	return nil
+}
+
+// Stats returns the current state of the progress
+func (p *progressTracker) Stats() ProgressStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.stats()
+}
+
+// isClosed returns if the progress has been closed
+func (p *progressTracker) isClosed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.closed
+}
+
+// changed renders the progress if forced or the interval has
+// passed since it was last rendered. The lock must be held
+// when called.
+func (p *progressTracker) changed(force bool) {
+	if p.closed || p.render == nil {
+		return
+	}
+
+	now := p.now()
+	if !force && !p.last.IsZero() && now.Sub(p.last) < p.interval {
+		return
+	}
+	p.last = now
+
+	p.render(p.stats(), false)
+}
+
+// stats returns the current state. The lock must be held when called.
+func (p *progressTracker) stats() ProgressStats {
+	s := ProgressStats{
+		Message: p.msg,
+		Total:   p.total,
+		Current: p.current,
+	}
+
+	elapsed := p.now().Sub(p.start).Seconds()
+	if elapsed > 0 {
+		s.Rate = float64(p.current-p.initial) / elapsed
+	}
+	if s.Rate > 0 && s.Total > s.Current {
+		s.ETA = time.Duration(float64(s.Total-s.Current) / s.Rate * float64(time.Second)).Round(time.Second)
+	}
+
+	return s
+}
+
+var _ Progress = (*progressTracker)(nil)
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package terminal
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressStats(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Stats    ProgressStats
+		Expected string
+	}{
+		{"unknown total", ProgressStats{Message: "Downloading", Current: 512}, "Downloading: 512 B"},
+		{"total", ProgressStats{Total: 4 << 20, Current: 1 << 20}, "1.0 MiB / 4.0 MiB (25%)"},
+		{"rate", ProgressStats{Total: 4 << 20, Current: 2 << 20, Rate: 1 << 20, ETA: 2 * time.Second},
+			"2.0 MiB / 4.0 MiB (50%), 1.0 MiB/s, ETA 2s"},
+		{"complete", ProgressStats{Total: 10, Current: 12}, "12 B / 10 B (100%)"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require.Equal(t, tt.Expected, tt.Stats.String())
+		})
+	}
+
+	require.Equal(t, "[=====     ]", ProgressStats{Total: 2, Current: 1}.bar(10))
+	require.Equal(t, "[???]", ProgressStats{}.bar(3))
+}
+
+func TestProgressTracker(t *testing.T) {
+	require := require.New(t)
+
+	var rendered []ProgressStats
+	var final []bool
+	p := newProgressTracker("Downloading", 10<<20, time.Second, func(s ProgressStats, f bool) {
+		rendered = append(rendered, s)
+		final = append(final, f)
+	})
+	now := p.start
+	p.now = func() time.Time { return now }
+	require.Len(rendered, 1)
+
+	// Changes within the interval are not rendered
+	now = now.Add(500 * time.Millisecond)
+	_, err := io.Copy(p, bytes.NewReader(make([]byte, 1<<20)))
+	require.NoError(err)
+	require.Len(rendered, 1)
+
+	now = now.Add(500 * time.Millisecond)
+	p.Add(1 << 20)
+	require.Len(rendered, 2)
+	require.Equal(int64(2<<20), rendered[1].Current)
+	require.Equal(float64(2<<20), rendered[1].Rate)
+	require.Equal(4*time.Second, rendered[1].ETA)
+
+	// Message changes are always rendered
+	p.Update("Extracting")
+	require.Len(rendered, 3)
+	require.Equal("Extracting", rendered[2].Message)
+
+	require.NoError(p.Close())
+	require.NoError(p.Close())
+	p.Add(1)
+	require.Len(rendered, 4)
+	require.Equal([]bool{false, false, false, true}, final)
+}
+
+func TestProgressTracker_resume(t *testing.T) {
+	require := require.New(t)
+
+	p := newProgressTracker("", 0, time.Second, nil)
+	now := p.start
+	p.now = func() time.Time { return now }
+
+	p.SetCurrent(100)
+	now = now.Add(2 * time.Second)
+	p.SetCurrent(300)
+
+	s := p.Stats()
+	require.Equal(float64(100), s.Rate)
+	require.Zero(s.ETA)
+}
+
+func TestRecordingUI_progress(t *testing.T) {
+	require := require.New(t)
+
+	ui := NewRecordingUI()
+	p := ui.Progress("Downloading", 10)
+	io.Copy(p, strings.NewReader("hello"))
+	p.SetTotal(20)
+	require.NoError(p.Close())
+
+	events := ui.Events()
+	require.Len(events, 4)
+	require.Equal(ActionStart, events[0].Action)
+	require.Equal(int64(5), events[1].Current)
+	require.Equal(int64(20), events[2].Total)
+	require.Equal(ActionClose, events[3].Action)
+
+	replayed := NewRecordingUI()
+	require.NoError(Replay(replayed, events))
+	require.Equal(events, replayed.Events())
+}
@@ -0,0 +1,745 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package terminal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Types of recorded events which are not output by the JSONFormat
+// machine readable UI
+const (
+	EventInput     = "input"
+	EventClearLine = "clear_line"
+	EventStepGroup = "step_group"
+)
+
+// Actions of recorded status, step group, and step events
+const (
+	ActionUpdate = "update"
+	ActionStep   = "step"
+	ActionClose  = "close"
+	ActionAdd    = "add"
+	ActionStatus = "status"
+	ActionDone   = "done"
+	ActionAbort  = "abort"
+	ActionWait   = "wait"
+	ActionStart  = "start"
+)
+
+// UpdateGoldenEnv is the environment variable which causes golden
+// files to be written instead of compared when set
+const UpdateGoldenEnv = "UPDATE_GOLDEN"
+
+// RecordedEvent is a single interaction with a RecordingUI. Options
+// given with the interaction are resolved into the event.
+type RecordedEvent struct {
+	Type   string `json:"type"`
+	Action string `json:"action,omitempty"`
+
+	// Status or step group the event applies to
+	ID int `json:"id,omitempty"`
+	// Step the event applies to
+	Step int `json:"step,omitempty"`
+
+	Message        string       `json:"message,omitempty"`
+	Style          string       `json:"style,omitempty"`
+	Color          string       `json:"color,omitempty"`
+	DisableNewLine bool         `json:"disable_new_line,omitempty"`
+	Target         string       `json:"target,omitempty"`
+	Status         string       `json:"status,omitempty"`
+	Values         []NamedValue `json:"values,omitempty"`
+	Table          *Table       `json:"table,omitempty"`
+	Input          *Input       `json:"input,omitempty"`
+
+	// Total and current bytes of a progress
+	Total   int64 `json:"total,omitempty"`
+	Current int64 `json:"current,omitempty"`
+
+	// Answer given to an input. The message of an input event is
+	// set to the reason the answer was not valid.
+	Answer string `json:"answer,omitempty"`
+	// Data written to output writers
+	Data string `json:"data,omitempty"`
+}
+
+// TestingT is the subset of testing.T used by the golden
+// file assertions
+type TestingT interface {
+	Helper()
+	Fatalf(format string, args ...interface{})
+}
+
+// RecordingUI is a UI which records all interactions as events.
+// Answers to Input are scripted using Answer and AnswerPrompt.
+// It is intended for use within tests.
+type RecordingUI struct {
+	mu      sync.Mutex
+	events  []*RecordedEvent
+	answers []string
+	prompts map[string]string
+	ids     int
+
+	// Result of MachineReadable
+	MachineReadableOutput bool
+}
+
+// NewRecordingUI returns a new RecordingUI
+func NewRecordingUI() *RecordingUI {
+	return &RecordingUI{prompts: map[string]string{}}
+}
+
+// Answer adds answers which are returned, in order, by Input
+// for prompts which do not have an answer set by AnswerPrompt
+func (ui *RecordingUI) Answer(answers ...string) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.answers = append(ui.answers, answers...)
+}
+
+// AnswerPrompt sets the answer returned by Input for the prompt
+func (ui *RecordingUI) AnswerPrompt(prompt, answer string) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.prompts[prompt] = answer
+}
+
+// Events returns the recorded events
+func (ui *RecordingUI) Events() []*RecordedEvent {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	return append([]*RecordedEvent{}, ui.events...)
+}
+
+// Reset removes all recorded events
+func (ui *RecordingUI) Reset() {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.events = nil
+	ui.ids = 0
+}
+
+// MarshalEvents returns the recorded events as indented JSON
+func (ui *RecordingUI) MarshalEvents() ([]byte, error) {
+	data, err := json.MarshalIndent(ui.Events(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+// AssertGolden fails the test if the recorded events do not match
+// the contents of the golden file at path. The golden file is
+// written instead when the UPDATE_GOLDEN environment variable is set.
+func (ui *RecordingUI) AssertGolden(t TestingT, path string) {
+	t.Helper()
+
+	actual, err := ui.MarshalEvents()
+	if err != nil {
+		t.Fatalf("failed to marshal recorded events: %s", err)
+		return
+	}
+
+	AssertGolden(t, path, actual)
+}
+
+// AssertGolden fails the test if actual does not match the contents
+// of the golden file at path. The golden file is written instead
+// when the UPDATE_GOLDEN environment variable is set.
+func AssertGolden(t TestingT, path string, actual []byte) {
+	t.Helper()
+
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create golden file directory: %s", err)
+			return
+		}
+		if err := os.WriteFile(path, actual, 0644); err != nil {
+			t.Fatalf("failed to write golden file: %s", err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (set %s=1 to create): %s", UpdateGoldenEnv, err)
+		return
+	}
+	if !bytes.Equal(expected, actual) {
+		t.Fatalf("output does not match golden file %s (set %s=1 to update)\n\nexpected:\n%s\nactual:\n%s",
+			path, UpdateGoldenEnv, expected, actual)
+	}
+}
+
+// LoadRecording reads recorded events from a file written by
+// MarshalEvents or AssertGolden
+func LoadRecording(path string) ([]*RecordedEvent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*RecordedEvent
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// Input implements UI. Scripted answers are resolved using the
+// input, so defaults, choices, and validation are applied. An answer
+// which is not valid is recorded and the next answer is used.
+func (ui *RecordingUI) Input(input *Input) (string, error) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	in := *input
+	in.Validate = nil
+	for {
+		answer, ok := ui.prompts[input.Prompt]
+		if !ok {
+			if len(ui.answers) == 0 {
+				return "", fmt.Errorf("no answer scripted for input %q", input.Prompt)
+			}
+			answer, ui.answers = ui.answers[0], ui.answers[1:]
+		}
+
+		// Secret answers are never recorded
+		recorded := answer
+		if input.Secret {
+			recorded = RedactedValue
+		}
+
+		result, err := input.Resolve(answer)
+		if err != nil {
+			ui.record(&RecordedEvent{Type: EventInput, Input: &in, Answer: recorded, Message: err.Error()})
+			if ok {
+				return "", err
+			}
+			continue
+		}
+
+		if !input.Secret {
+			recorded = result
+		}
+		ui.record(&RecordedEvent{Type: EventInput, Input: &in, Answer: recorded})
+
+		return result, nil
+	}
+}
+
+// Interactive implements UI
+func (ui *RecordingUI) Interactive() bool {
+	return true
+}
+
+// MachineReadable implements UI
+func (ui *RecordingUI) MachineReadable() bool {
+	return ui.MachineReadableOutput
+}
+
+// Output implements UI
+func (ui *RecordingUI) Output(msg string, raw ...interface{}) {
+	var args []interface{}
+	var opts []Option
+	for _, r := range raw {
+		if opt, ok := r.(Option); ok {
+			opts = append(opts, opt)
+		} else {
+			args = append(args, r)
+		}
+	}
+	cfg := recordingConfig(opts)
+
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.record(&RecordedEvent{
+		Type:           EventOutput,
+		Message:        Redact(fmt.Sprintf(msg, args...)),
+		Style:          cfg.Style,
+		Color:          cfg.Color,
+		DisableNewLine: cfg.DisableNewLine,
+		Target:         cfg.Target,
+	})
+}
+
+// ClearLine implements UI
+func (ui *RecordingUI) ClearLine() {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.record(&RecordedEvent{Type: EventClearLine})
+}
+
+// NamedValues implements UI
+func (ui *RecordingUI) NamedValues(rows []NamedValue, opts ...Option) {
+	values := make([]NamedValue, len(rows))
+	for i, row := range rows {
+		values[i] = row
+		if s, ok := row.Value.(string); ok {
+			values[i].Value = Redact(s)
+		}
+	}
+	cfg := recordingConfig(opts)
+
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.record(&RecordedEvent{Type: EventNamedValues, Values: values, Target: cfg.Target})
+}
+
+// OutputWriters implements UI
+func (ui *RecordingUI) OutputWriters() (io.Writer, io.Writer, error) {
+	return RedactWriter(&recordingWriter{ui: ui, typ: EventStdout}),
+		RedactWriter(&recordingWriter{ui: ui, typ: EventStderr}), nil
+}
+
+// Status implements UI
+func (ui *RecordingUI) Status() Status {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.ids++
+
+	return &recordingStatus{ui: ui, id: ui.ids}
+}
+
+// Progress implements UI. Every change is recorded with the
+// total and current bytes of the progress.
+func (ui *RecordingUI) Progress(msg string, total int64) Progress {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.ids++
+	p := &recordingProgress{ui: ui, id: ui.ids, msg: Redact(msg), total: total}
+	p.record(ActionStart)
+
+	return p
+}
+
+// StepGroup implements UI
+func (ui *RecordingUI) StepGroup() StepGroup {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.ids++
+
+	return &recordingStepGroup{ui: ui, id: ui.ids}
+}
+
+// Table implements UI
+func (ui *RecordingUI) Table(tbl *Table, opts ...Option) {
+	tbl = redactTable(tbl)
+	cfg := recordingConfig(opts)
+
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.record(&RecordedEvent{Type: EventTable, Table: tbl, Target: cfg.Target})
+}
+
+// record adds the event. The lock must be held when called.
+func (ui *RecordingUI) record(e *RecordedEvent) {
+	ui.events = append(ui.events, e)
+}
+
+func recordingConfig(opts []Option) *config {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return cfg
+}
+
+type recordingWriter struct {
+	ui   *RecordingUI
+	typ  string
+	id   int
+	step int
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	w.ui.mu.Lock()
+	defer w.ui.mu.Unlock()
+
+	w.ui.record(&RecordedEvent{Type: w.typ, ID: w.id, Step: w.step, Data: string(p)})
+
+	return len(p), nil
+}
+
+type recordingStatus struct {
+	ui *RecordingUI
+	id int
+}
+
+func (s *recordingStatus) Update(msg string) {
+	s.ui.mu.Lock()
+	defer s.ui.mu.Unlock()
+
+	s.ui.record(&RecordedEvent{Type: EventStatus, Action: ActionUpdate, ID: s.id, Message: Redact(msg)})
+}
+
+func (s *recordingStatus) Step(status, msg string) {
+	s.ui.mu.Lock()
+	defer s.ui.mu.Unlock()
+
+	s.ui.record(&RecordedEvent{
+		Type:    EventStatus,
+		Action:  ActionStep,
+		ID:      s.id,
+		Status:  status,
+		Message: Redact(msg),
+	})
+}
+
+func (s *recordingStatus) Close() error {
+	s.ui.mu.Lock()
+	defer s.ui.mu.Unlock()
+
+	s.ui.record(&RecordedEvent{Type: EventStatus, Action: ActionClose, ID: s.id})
+
+	return nil
+}
+
+type recordingProgress struct {
+	ui      *RecordingUI
+	id      int
+	msg     string
+	total   int64
+	current int64
+}
+
+func (p *recordingProgress) Write(b []byte) (int, error) {
+	p.Add(int64(len(b)))
+	return len(b), nil
+}
+
+func (p *recordingProgress) Update(msg string) {
+	p.ui.mu.Lock()
+	defer p.ui.mu.Unlock()
+
+	p.msg = Redact(msg)
+	p.record(ActionUpdate)
+}
+
+func (p *recordingProgress) SetTotal(total int64) {
+	p.ui.mu.Lock()
+	defer p.ui.mu.Unlock()
+
+	p.total = total
+	p.record(ActionUpdate)
+}
+
+func (p *recordingProgress) SetCurrent(current int64) {
+	p.ui.mu.Lock()
+	defer p.ui.mu.Unlock()
+
+	p.current = current
+	p.record(ActionUpdate)
+}
+
+func (p *recordingProgress) Add(n int64) {
+	p.ui.mu.Lock()
+	defer p.ui.mu.Unlock()
+
+	p.current += n
+	p.record(ActionUpdate)
+}
+
+func (p *recordingProgress) Close() error {
+	p.ui.mu.Lock()
+	defer p.ui.mu.Unlock()
+
+	p.record(ActionClose)
+
+	return nil
+}
+
+// record adds an event with the state of the progress. The lock
+// of the UI must be held when called.
+func (p *recordingProgress) record(action string) {
+	p.ui.record(&RecordedEvent{
+		Type:    EventProgress,
+		Action:  action,
+		ID:      p.id,
+		Message: p.msg,
+		Total:   p.total,
+		Current: p.current,
+	})
+}
+
+type recordingStepGroup struct {
+	ui     *RecordingUI
+	id     int
+	steps  int
+	wg     sync.WaitGroup
+	closed bool
+}
+
+func (g *recordingStepGroup) Add(str string, args ...interface{}) Step {
+	g.ui.mu.Lock()
+	defer g.ui.mu.Unlock()
+
+	g.steps++
+	step := &recordingStep{ui: g.ui, id: g.id, step: g.steps}
+	g.ui.record(&RecordedEvent{
+		Type:    EventStep,
+		Action:  ActionAdd,
+		ID:      g.id,
+		Step:    step.step,
+		Message: Redact(fmt.Sprintf(str, args...)),
+	})
+
+	// If we're closed we don't add this step to our waitgroup.
+	// We still return a non-nil step so downstreams don't crash.
+	if !g.closed {
+		step.wg = &g.wg
+		g.wg.Add(1)
+	}
+
+	return step
+}
+
+func (g *recordingStepGroup) Wait() {
+	g.ui.mu.Lock()
+	g.closed = true
+	wg := &g.wg
+	g.ui.mu.Unlock()
+
+	wg.Wait()
+
+	g.ui.mu.Lock()
+	defer g.ui.mu.Unlock()
+
+	g.ui.record(&RecordedEvent{Type: EventStepGroup, Action: ActionWait, ID: g.id})
+}
+
+type recordingStep struct {
+	ui   *RecordingUI
+	id   int
+	step int
+	wg   *sync.WaitGroup
+	done bool
+	out  io.Writer
+}
+
+func (s *recordingStep) TermOutput() io.Writer {
+	s.ui.mu.Lock()
+	defer s.ui.mu.Unlock()
+
+	if s.out == nil {
+		s.out = RedactWriter(&recordingWriter{ui: s.ui, typ: EventStepOutput, id: s.id, step: s.step})
+	}
+
+	return s.out
+}
+
+func (s *recordingStep) Update(str string, args ...interface{}) {
+	s.ui.mu.Lock()
+	defer s.ui.mu.Unlock()
+
+	s.ui.record(&RecordedEvent{
+		Type:    EventStep,
+		Action:  ActionUpdate,
+		ID:      s.id,
+		Step:    s.step,
+		Message: Redact(fmt.Sprintf(str, args...)),
+	})
+}
+
+func (s *recordingStep) Status(status string) {
+	s.ui.mu.Lock()
+	defer s.ui.mu.Unlock()
+
+	s.ui.record(&RecordedEvent{Type: EventStep, Action: ActionStatus, ID: s.id, Step: s.step, Status: status})
+}
+
+func (s *recordingStep) Done() {
+	s.finish(ActionDone)
+}
+
+func (s *recordingStep) Abort() {
+	s.finish(ActionAbort)
+}
+
+func (s *recordingStep) finish(action string) {
+	s.ui.mu.Lock()
+	defer s.ui.mu.Unlock()
+
+	if s.done {
+		return
+	}
+	s.done = true
+	s.ui.record(&RecordedEvent{Type: EventStep, Action: action, ID: s.id, Step: s.step})
+
+	if s.wg != nil {
+		s.wg.Done()
+	}
+}
+
+// Replay renders the recorded events using the UI. Input events are
+// rendered as output of the prompt and answer, since the answers
+// were scripted when recorded. Answers which were not valid are
+// rendered as errors.
+func Replay(ui UI, events []*RecordedEvent) error {
+	var stdout, stderr io.Writer
+	statuses := map[int]Status{}
+	progresses := map[int]Progress{}
+	progressed := map[int]*RecordedEvent{}
+	groups := map[int]StepGroup{}
+	steps := map[[2]int]Step{}
+
+	step := func(e *RecordedEvent) (Step, error) {
+		s, ok := steps[[2]int{e.ID, e.Step}]
+		if !ok {
+			return nil, fmt.Errorf("step %d of step group %d was not added", e.Step, e.ID)
+		}
+		return s, nil
+	}
+
+	for _, e := range events {
+		switch e.Type {
+		case EventOutput:
+			opts := []interface{}{WithStyle(e.Style), WithColor(e.Color), WithTarget(e.Target)}
+			if e.DisableNewLine {
+				opts = append(opts, WithoutNewLine())
+			}
+			ui.Output("%s", append([]interface{}{e.Message}, opts...)...)
+		case EventInput:
+			if e.Input != nil {
+				ui.Output("%s %s", e.Input.Display(), e.Answer)
+			}
+			if e.Message != "" {
+				ui.Output("%s", e.Message, WithErrorStyle())
+			}
+		case EventClearLine:
+			ui.ClearLine()
+		case EventNamedValues:
+			ui.NamedValues(e.Values, WithTarget(e.Target))
+		case EventTable:
+			if e.Table != nil {
+				ui.Table(e.Table, WithTarget(e.Target))
+			}
+		case EventStdout, EventStderr:
+			if stdout == nil {
+				var err error
+				if stdout, stderr, err = ui.OutputWriters(); err != nil {
+					return err
+				}
+			}
+			w := stdout
+			if e.Type == EventStderr {
+				w = stderr
+			}
+			if _, err := io.WriteString(w, e.Data); err != nil {
+				return err
+			}
+		case EventStatus:
+			s, ok := statuses[e.ID]
+			if !ok {
+				s = ui.Status()
+				statuses[e.ID] = s
+			}
+			switch e.Action {
+			case ActionUpdate:
+				s.Update(e.Message)
+			case ActionStep:
+				s.Step(e.Status, e.Message)
+			case ActionClose:
+				if err := s.Close(); err != nil {
+					return err
+				}
+				delete(statuses, e.ID)
+			}
+		case EventProgress:
+			if e.Action == ActionStart {
+				progresses[e.ID] = ui.Progress(e.Message, e.Total)
+				progressed[e.ID] = e
+				continue
+			}
+			p, ok := progresses[e.ID]
+			if !ok {
+				return fmt.Errorf("progress %d was not started", e.ID)
+			}
+			last := progressed[e.ID]
+			progressed[e.ID] = e
+			switch e.Action {
+			case ActionUpdate:
+				// Only apply changes so each is rendered once
+				if e.Message != last.Message {
+					p.Update(e.Message)
+				}
+				if e.Total != last.Total {
+					p.SetTotal(e.Total)
+				}
+				if e.Current != last.Current {
+					p.SetCurrent(e.Current)
+				}
+			case ActionClose:
+				if err := p.Close(); err != nil {
+					return err
+				}
+				delete(progresses, e.ID)
+				delete(progressed, e.ID)
+			}
+		case EventStep:
+			if e.Action == ActionAdd {
+				g, ok := groups[e.ID]
+				if !ok {
+					g = ui.StepGroup()
+					groups[e.ID] = g
+				}
+				steps[[2]int{e.ID, e.Step}] = g.Add("%s", e.Message)
+				continue
+			}
+			s, err := step(e)
+			if err != nil {
+				return err
+			}
+			switch e.Action {
+			case ActionUpdate:
+				s.Update("%s", e.Message)
+			case ActionStatus:
+				s.Status(e.Status)
+			case ActionDone:
+				s.Done()
+			case ActionAbort:
+				s.Abort()
+			}
+		case EventStepOutput:
+			s, err := step(e)
+			if err != nil {
+				return err
+			}
+			if _, err := io.WriteString(s.TermOutput(), e.Data); err != nil {
+				return err
+			}
+		case EventStepGroup:
+			if g, ok := groups[e.ID]; ok {
+				g.Wait()
+				delete(groups, e.ID)
+			}
+		default:
+			return fmt.Errorf("unknown recorded event type %q", e.Type)
+		}
+	}
+
+	return nil
+}
+
+var _ UI = (*RecordingUI)(nil)
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package terminal
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testRecording(t *testing.T) *RecordingUI {
+	ui := NewRecordingUI()
+	ui.Answer("yes")
+	ui.AnswerPrompt("Name?", "test")
+
+	name, err := ui.Input(&Input{Prompt: "Name?"})
+	require.NoError(t, err)
+	require.Equal(t, "test", name)
+	answer, err := ui.Input(&Input{Prompt: "Continue?"})
+	require.NoError(t, err)
+	require.Equal(t, "yes", answer)
+	_, err = ui.Input(&Input{Prompt: "Again?"})
+	require.Error(t, err)
+
+	ui.Output("Hello %s", name, WithHeaderStyle(), WithTarget("default"))
+	ui.NamedValues([]NamedValue{{Name: "box", Value: "hashicorp/bionic64"}})
+	tbl := NewTable("Name", "State")
+	tbl.Rich([]string{"default", "running"}, []string{"", "green"})
+	ui.Table(tbl)
+
+	s := ui.Status()
+	s.Update("Waiting")
+	s.Step(StatusOK, "Ready")
+	s.Close()
+
+	sg := ui.StepGroup()
+	step := sg.Add("Step one")
+	fmt.Fprint(step.TermOutput(), "working\n")
+	step.Status(StatusWarn)
+	step.Done()
+	sg.Add("Step two").Abort()
+	sg.Wait()
+
+	stdout, _, err := ui.OutputWriters()
+	require.NoError(t, err)
+	fmt.Fprint(stdout, "done\n")
+
+	return ui
+}
+
+func TestRecordingUI_golden(t *testing.T) {
+	testRecording(t).AssertGolden(t, filepath.Join("testdata", "recording.golden"))
+}
+
+func TestRecordingUI_replay(t *testing.T) {
+	require := require.New(t)
+
+	events, err := LoadRecording(filepath.Join("testdata", "recording.golden"))
+	require.NoError(err)
+	require.Equal(testRecording(t).Events()[2:], mustRoundTrip(t, events)[2:])
+
+	replayed := NewRecordingUI()
+	require.NoError(Replay(replayed, events))
+
+	result := replayed.Events()
+	require.Len(result, len(events))
+	require.Equal(EventOutput, result[0].Type)
+	require.Equal("Name? test", result[0].Message)
+	require.Equal(events[2:], mustRoundTrip(t, result)[2:])
+}
+
+// mustRoundTrip returns the events after encoding and decoding them
+// so values match those loaded from a recording
+func mustRoundTrip(t *testing.T, events []*RecordedEvent) []*RecordedEvent {
+	data, err := json.Marshal(events)
+	require.NoError(t, err)
+
+	var result []*RecordedEvent
+	require.NoError(t, json.Unmarshal(data, &result))
+
+	return result
+}
+
+func TestRecordingUI_secretInput(t *testing.T) {
+	require := require.New(t)
+
+	ui := NewRecordingUI()
+	ui.Answer("hunter2")
+
+	answer, err := ui.Input(&Input{Prompt: "Password?", Secret: true})
+	require.NoError(err)
+	require.Equal("hunter2", answer)
+
+	events := ui.Events()
+	require.Len(events, 1)
+	require.Equal(RedactedValue, events[0].Answer)
+}
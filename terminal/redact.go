@@ -0,0 +1,259 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package terminal
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// flusher is implemented by writers which hold data, like the
+// writer returned from RedactWriter
+type flusher interface {
+	Flush() error
+}
+
+// flushOutput writes any data held by the writer
+func flushOutput(w io.Writer) {
+	if f, ok := w.(flusher); ok {
+		f.Flush()
+	}
+}
+
+// RedactedValue replaces sensitive values within output
+const RedactedValue = "*****"
+
+var sensitive = &sensitiveValues{}
+
+type sensitiveValues struct {
+	mu       sync.RWMutex
+	values   []string
+	replacer *strings.Replacer
+}
+
+// AddSensitive registers values which must never be displayed. Any
+// registered value found within output from a UI, or written to a
+// writer returned from RedactWriter, is replaced with RedactedValue.
+func AddSensitive(values ...string) {
+	sensitive.mu.Lock()
+	defer sensitive.mu.Unlock()
+
+	existing := map[string]struct{}{}
+	for _, v := range sensitive.values {
+		existing[v] = struct{}{}
+	}
+
+	for _, v := range values {
+		// Values which are too short would redact
+		// most output
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		if _, ok := existing[v]; ok {
+			continue
+		}
+		existing[v] = struct{}{}
+		sensitive.values = append(sensitive.values, v)
+	}
+
+	// Longer values are replaced first so values containing
+	// other values are fully redacted
+	sort.SliceStable(sensitive.values, func(i, j int) bool {
+		return len(sensitive.values[i]) > len(sensitive.values[j])
+	})
+
+	pairs := make([]string, 0, len(sensitive.values)*2)
+	for _, v := range sensitive.values {
+		pairs = append(pairs, v, RedactedValue)
+	}
+	sensitive.replacer = strings.NewReplacer(pairs...)
+}
+
+// SensitiveValues returns the registered sensitive values
+func SensitiveValues() []string {
+	sensitive.mu.RLock()
+	defer sensitive.mu.RUnlock()
+
+	return append([]string{}, sensitive.values...)
+}
+
+// ClearSensitive removes all registered sensitive values
+func ClearSensitive() {
+	sensitive.mu.Lock()
+	defer sensitive.mu.Unlock()
+
+	sensitive.values = nil
+	sensitive.replacer = nil
+}
+
+// Redact replaces any sensitive values within the string
+func Redact(s string) string {
+	sensitive.mu.RLock()
+	defer sensitive.mu.RUnlock()
+
+	if sensitive.replacer == nil {
+		return s
+	}
+
+	return sensitive.replacer.Replace(s)
+}
+
+// partialMatch returns the length of the longest suffix of b
+// which is the beginning of a sensitive value
+func partialMatch(b []byte) int {
+	sensitive.mu.RLock()
+	defer sensitive.mu.RUnlock()
+
+	longest := 0
+	for _, v := range sensitive.values {
+		max := len(v) - 1
+		if max > len(b) {
+			max = len(b)
+		}
+		for n := max; n > longest; n-- {
+			if bytes.HasPrefix([]byte(v), b[len(b)-n:]) {
+				longest = n
+				break
+			}
+		}
+	}
+
+	return longest
+}
+
+// redactHoldTimeout is the longest time data is held by a writer
+// returned from RedactWriter before being written
+var redactHoldTimeout = 100 * time.Millisecond
+
+// RedactWriter returns a writer which replaces any sensitive values
+// before writing to w. When written data ends with the beginning of
+// a sensitive value, that data is held until the next write so values
+// split across writes are still redacted. Held data is written once a
+// new line is written, when the writer is flushed, synced or closed,
+// or after a short timeout, so output like a prompt without a new line
+// is never delayed. Values split across writes are only redacted when
+// they do not span lines.
+func RedactWriter(w io.Writer) io.Writer {
+	if _, ok := w.(*redactWriter); ok {
+		return w
+	}
+
+	return &redactWriter{w: w}
+}
+
+type redactWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	pending []byte
+	timer   *time.Timer
+}
+
+func (r *redactWriter) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data := append(r.pending, p...)
+	hold := partialMatch(data)
+
+	// Data is only held until the end of the line so complete
+	// lines are always written without needing to be flushed
+	if i := bytes.LastIndexByte(data, '\n'); i >= 0 && hold > len(data)-i-1 {
+		hold = len(data) - i - 1
+	}
+	r.pending = append([]byte{}, data[len(data)-hold:]...)
+	r.schedule()
+
+	out := Redact(string(data[:len(data)-hold]))
+	if len(out) == 0 {
+		return len(p), nil
+	}
+	if _, err := io.WriteString(r.w, out); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// schedule starts, or stops, the timer which writes held data
+// once the hold timeout has elapsed
+func (r *redactWriter) schedule() {
+	if len(r.pending) == 0 {
+		if r.timer != nil {
+			r.timer.Stop()
+		}
+		return
+	}
+
+	if r.timer == nil {
+		r.timer = time.AfterFunc(redactHoldTimeout, func() { r.Flush() })
+		return
+	}
+	r.timer.Reset(redactHoldTimeout)
+}
+
+// Flush writes any data held while waiting to determine if
+// it is the beginning of a sensitive value
+func (r *redactWriter) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	if len(r.pending) == 0 {
+		return nil
+	}
+
+	_, err := io.WriteString(r.w, Redact(string(r.pending)))
+	r.pending = nil
+
+	return err
+}
+
+// Sync writes any held data. It allows the writer to be synced
+// like an *os.File.
+func (r *redactWriter) Sync() error {
+	return r.Flush()
+}
+
+// Close flushes any held data and closes the underlying
+// writer if it is closable
+func (r *redactWriter) Close() error {
+	if err := r.Flush(); err != nil {
+		return err
+	}
+
+	if c, ok := r.w.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
+// redactTable returns a copy of the table with any sensitive
+// values redacted
+func redactTable(tbl *Table) *Table {
+	result := &Table{
+		Headers: make([]string, len(tbl.Headers)),
+		Rows:    make([][]TableEntry, len(tbl.Rows)),
+		Columns: tbl.Columns,
+		Width:   tbl.Width,
+	}
+	for i, h := range tbl.Headers {
+		result.Headers[i] = Redact(h)
+	}
+	for i, row := range tbl.Rows {
+		result.Rows[i] = make([]TableEntry, len(row))
+		for j, ent := range row {
+			ent.Value = Redact(ent.Value)
+			result.Rows[i][j] = ent
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package terminal
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	require := require.New(t)
+	t.Cleanup(ClearSensitive)
+
+	require.Equal("token abc", Redact("token abc"))
+
+	AddSensitive("abc", "abcdef", "  ")
+	require.Equal("token *****", Redact("token abc"))
+	require.Equal("token ***** *****", Redact("token abcdef abc"))
+	require.Equal("token  ", Redact("token  "))
+
+	ClearSensitive()
+	require.Equal("token abc", Redact("token abc"))
+}
+
+func TestRedactWriter(t *testing.T) {
+	require := require.New(t)
+	t.Cleanup(ClearSensitive)
+	AddSensitive("supersecret")
+
+	var buf bytes.Buffer
+	w := RedactWriter(&buf)
+	for _, chunk := range []string{"the value is sup", "er", "secret!\n", "done su"} {
+		_, err := w.Write([]byte(chunk))
+		require.NoError(err)
+	}
+	require.Equal("the value is *****!\ndone ", buf.String())
+
+	require.NoError(w.(*redactWriter).Flush())
+	require.Equal("the value is *****!\ndone su", buf.String())
+	require.Equal(w, RedactWriter(w))
+
+	// Held data is written at the end of the line
+	buf.Reset()
+	for _, chunk := range []string{"\nnext su", "\n"} {
+		_, err := w.Write([]byte(chunk))
+		require.NoError(err)
+	}
+	require.Equal("\nnext su\n", buf.String())
+}
+
+func TestRedactWriter_prompt(t *testing.T) {
+	require := require.New(t)
+	t.Cleanup(ClearSensitive)
+	AddSensitive("Password: hunter2")
+
+	var buf syncBuffer
+	w := RedactWriter(&buf)
+
+	// The prompt is the beginning of a sensitive value so it is
+	// held, but only until the hold timeout has elapsed
+	_, err := w.Write([]byte("Password: "))
+	require.NoError(err)
+	require.Equal("", buf.String())
+	require.Eventually(func() bool {
+		return buf.String() == "Password: "
+	}, 10*redactHoldTimeout, redactHoldTimeout/10)
+
+	// Syncing writes held data immediately
+	buf.Reset()
+	_, err = w.Write([]byte("Password: "))
+	require.NoError(err)
+	require.NoError(w.(interface{ Sync() error }).Sync())
+	require.Equal("Password: ", buf.String())
+}
+
+// syncBuffer is a bytes.Buffer which is safe for concurrent use
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func (b *syncBuffer) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf.Reset()
+}
+
+func TestRedact_nonInteractiveUI(t *testing.T) {
+	require := require.New(t)
+	t.Cleanup(ClearSensitive)
+	AddSensitive("supersecret")
+
+	var buf bytes.Buffer
+	orig := color.Output
+	color.Output = &buf
+	t.Cleanup(func() { color.Output = orig })
+
+	ui := NonInteractiveUI(context.Background())
+	ui.Output("password: %s", "supersecret")
+	ui.NamedValues([]NamedValue{{"password", "supersecret"}})
+	ui.Table(&Table{
+		Headers: []string{"name", "value"},
+		Rows:    [][]TableEntry{{{Value: "password"}, {Value: "supersecret"}}},
+	})
+
+	s := ui.Status()
+	s.Update("using supersecret")
+	s.Step(StatusOK, "used supersecret")
+	s.Close()
+
+	sg := ui.StepGroup()
+	step := sg.Add("step with %s", "supersecret")
+	step.TermOutput().Write([]byte("output supersecret\n"))
+	step.Update("updated supersecret")
+	step.Done()
+	sg.Wait()
+
+	require.Contains(buf.String(), RedactedValue)
+	require.NotContains(buf.String(), "supersecret")
+}
+
+func TestRedact_logger(t *testing.T) {
+	require := require.New(t)
+	t.Cleanup(ClearSensitive)
+	AddSensitive("supersecret")
+
+	var buf bytes.Buffer
+	log := hclog.New(&hclog.LoggerOptions{
+		Output:     RedactWriter(&buf),
+		JSONFormat: true,
+	})
+	log.Info("connecting", "password", "supersecret")
+
+	require.Contains(buf.String(), RedactedValue)
+	require.NotContains(buf.String(), "supersecret")
+}
+
+func TestRedact_machineReadableUI(t *testing.T) {
+	require := require.New(t)
+	t.Cleanup(ClearSensitive)
+	AddSensitive("super,secret")
+
+	var buf bytes.Buffer
+	orig := color.Output
+	color.Output = &buf
+	t.Cleanup(func() { color.Output = orig })
+
+	ui := MachineReadableUI(context.Background(), TableFormat)
+	ui.Output("password super,secret")
+
+	require.Contains(buf.String(), "password "+RedactedValue)
+	require.NotContains(buf.String(), "super")
+	require.NotContains(buf.String(), "secret")
+}
@@ -0,0 +1,365 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package terminal
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/olekukonko/tablewriter"
+)
+
+// Colors used for the prefix of scoped output. Red is not included
+// so scoped output is not mistaken for errors.
+var scopeColors = []string{
+	"cyan", "magenta", "blue", "green", "yellow",
+	"lightCyan", "lightMagenta", "lightBlue",
+}
+
+// scopeColor returns the color for the name. The same name
+// always has the same color.
+func scopeColor(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return scopeColors[h.Sum32()%uint32(len(scopeColors))]
+}
+
+// Scoped returns a UI which prefixes every line of output with the
+// name, like the name of a machine. Like Vagrant, the first line of
+// each message is prefixed with "==> name: " and any other lines, like
+// the lines of a table or of command output, are indented to match.
+// Output is colored using a color unique to the name unless it has a
+// color of its own. Each line is written to the UI with a single call
+// so output from several scoped UIs may be used in parallel.
+//
+// If the UI is machine readable no prefix is added and the name is
+// set as the target of the output instead.
+func Scoped(ui UI, name string) UI {
+	return &scopedUI{
+		UI:     ui,
+		name:   name,
+		header: "==> " + name + ": ",
+		prefix: "    " + name + ": ",
+		color:  scopeColor(name),
+	}
+}
+
+type scopedUI struct {
+	UI
+
+	name   string
+	header string // prefix of the first line of a message
+	prefix string // prefix of all other lines
+	color  string
+
+	mu      sync.Mutex
+	midLine bool // Last output did not end with a new line
+}
+
+// Input implements UI
+func (ui *scopedUI) Input(input *Input) (string, error) {
+	if ui.MachineReadable() {
+		return ui.UI.Input(input)
+	}
+
+	in := *input
+	in.Prompt = ui.header + in.Prompt
+	if in.Color == "" {
+		in.Color = ui.color
+	}
+
+	return ui.UI.Input(&in)
+}
+
+// Output implements UI
+func (ui *scopedUI) Output(msg string, raw ...interface{}) {
+	ui.output(ui.header, msg, raw...)
+}
+
+// output outputs the message with the first line prefixed with
+// first and all other lines prefixed with the prefix
+func (ui *scopedUI) output(first, msg string, raw ...interface{}) {
+	var args []interface{}
+	var opts []interface{}
+	for _, r := range raw {
+		if _, ok := r.(Option); ok {
+			opts = append(opts, r)
+		} else {
+			args = append(args, r)
+		}
+	}
+
+	cfg := &config{}
+	for _, opt := range opts {
+		opt.(Option)(cfg)
+	}
+	if cfg.Target == "" {
+		opts = append(opts, WithTarget(ui.name))
+	}
+
+	msg = fmt.Sprintf(msg, args...)
+	if !ui.MachineReadable() {
+		ui.mu.Lock()
+		msg = ui.prefixLines(msg, first, ui.midLine)
+		ui.midLine = cfg.DisableNewLine
+		ui.mu.Unlock()
+
+		if cfg.Color == "" {
+			opts = append(opts, WithColor(ui.color))
+		}
+	}
+
+	ui.UI.Output("%s", append([]interface{}{msg}, opts...)...)
+}
+
+// NamedValues implements UI. The values are output as lines
+// so each can be prefixed.
+func (ui *scopedUI) NamedValues(rows []NamedValue, opts ...Option) {
+	if ui.MachineReadable() {
+		ui.UI.NamedValues(rows, append([]Option{WithTarget(ui.name)}, opts...)...)
+		return
+	}
+
+	var buf bytes.Buffer
+	tr := tabwriter.NewWriter(&buf, 1, 8, 0, ' ', tabwriter.AlignRight)
+	for _, row := range rows {
+		switch v := row.Value.(type) {
+		case int, uint, int8, uint8, int16, uint16, int32, uint32, int64, uint64:
+			fmt.Fprintf(tr, "%s: \t%d\n", row.Name, row.Value)
+		case float32, float64:
+			fmt.Fprintf(tr, "%s: \t%f\n", row.Name, row.Value)
+		case bool:
+			fmt.Fprintf(tr, "%s: \t%v\n", row.Name, row.Value)
+		case string:
+			if v == "" {
+				continue
+			}
+			fmt.Fprintf(tr, "%s: \t%s\n", row.Name, row.Value)
+		default:
+			fmt.Fprintf(tr, "%s: \t%s\n", row.Name, row.Value)
+		}
+	}
+	tr.Flush()
+
+	ui.outputText(buf.String(), opts)
+}
+
+// OutputWriters implements UI
+func (ui *scopedUI) OutputWriters() (io.Writer, io.Writer, error) {
+	stdout, stderr, err := ui.UI.OutputWriters()
+	if err != nil || ui.MachineReadable() {
+		return stdout, stderr, err
+	}
+
+	return &prefixWriter{w: stdout, prefix: ui.prefix},
+		&prefixWriter{w: stderr, prefix: ui.prefix}, nil
+}
+
+// Status implements UI
+func (ui *scopedUI) Status() Status {
+	return &scopedStatus{Status: ui.UI.Status(), ui: ui}
+}
+
+// Progress implements UI
+func (ui *scopedUI) Progress(msg string, total int64) Progress {
+	return &scopedProgress{Progress: ui.UI.Progress(ui.scope(msg), total), ui: ui}
+}
+
+// StepGroup implements UI
+func (ui *scopedUI) StepGroup() StepGroup {
+	return &scopedStepGroup{StepGroup: ui.UI.StepGroup(), ui: ui}
+}
+
+// Table implements UI. The table is output as lines so each
+// can be prefixed.
+func (ui *scopedUI) Table(tbl *Table, opts ...Option) {
+	if ui.MachineReadable() {
+		ui.UI.Table(tbl, append([]Option{WithTarget(ui.name)}, opts...)...)
+		return
+	}
+
+	// Leave room for the prefix and indentation of each line
+	width := tableWidth(tbl, true)
+	if width > 0 {
+		width -= runewidth.StringWidth(ui.prefix) + 2
+	}
+	tbl = redactTable(tbl).fit(width, true)
+	var buf bytes.Buffer
+	table := tablewriter.NewWriter(&buf)
+	table.SetHeader(tbl.Headers)
+	table.SetBorder(false)
+	table.SetAutoWrapText(false)
+
+	for _, row := range tbl.Rows {
+		colors := make([]tablewriter.Colors, len(row))
+		entries := make([]string, len(row))
+
+		for i, ent := range row {
+			entries[i] = ent.Value
+
+			color, ok := colorMapping[ent.Color]
+			if ok {
+				colors[i] = tablewriter.Colors{color}
+			}
+		}
+
+		table.Rich(entries, colors)
+	}
+
+	table.Render()
+
+	ui.outputText(buf.String(), opts)
+}
+
+// outputText outputs the text with each line prefixed. The
+// text is not a message, so the first line is indented like
+// the others.
+func (ui *scopedUI) outputText(text string, opts []Option) {
+	raw := []interface{}{strings.TrimRight(text, "\n")}
+	for _, opt := range opts {
+		raw = append(raw, opt)
+	}
+
+	ui.output(ui.prefix, "%s", raw...)
+}
+
+// scope returns the message prefixed with the name when the
+// UI is not machine readable
+func (ui *scopedUI) scope(msg string) string {
+	if ui.MachineReadable() {
+		return msg
+	}
+
+	return ui.prefixLines(msg, ui.header, false)
+}
+
+// prefixLines returns the text with the first line prefixed with
+// first and all other lines prefixed with the prefix. The first
+// line is not prefixed when continuing a line, and the empty line
+// following a trailing new line is not prefixed.
+func (ui *scopedUI) prefixLines(text, first string, continued bool) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if (i == 0 && continued) || (i > 0 && i == len(lines)-1 && line == "") {
+			continue
+		}
+		if i == 0 {
+			lines[i] = first + line
+		} else {
+			lines[i] = ui.prefix + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type scopedStatus struct {
+	Status
+	ui *scopedUI
+}
+
+func (s *scopedStatus) Update(msg string) {
+	s.Status.Update(s.ui.scope(msg))
+}
+
+func (s *scopedStatus) Step(status, msg string) {
+	s.Status.Step(status, s.ui.scope(msg))
+}
+
+type scopedProgress struct {
+	Progress
+	ui *scopedUI
+}
+
+func (p *scopedProgress) Update(msg string) {
+	p.Progress.Update(p.ui.scope(msg))
+}
+
+type scopedStepGroup struct {
+	StepGroup
+	ui *scopedUI
+}
+
+func (g *scopedStepGroup) Add(str string, args ...interface{}) Step {
+	return &scopedStep{
+		Step: g.StepGroup.Add("%s", g.ui.scope(fmt.Sprintf(str, args...))),
+		ui:   g.ui,
+	}
+}
+
+type scopedStep struct {
+	Step
+	ui *scopedUI
+
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func (s *scopedStep) TermOutput() io.Writer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.out == nil {
+		s.out = s.Step.TermOutput()
+		if !s.ui.MachineReadable() {
+			s.out = &prefixWriter{w: s.out, prefix: s.ui.prefix}
+		}
+	}
+
+	return s.out
+}
+
+func (s *scopedStep) Update(str string, args ...interface{}) {
+	s.Step.Update("%s", s.ui.scope(fmt.Sprintf(str, args...)))
+}
+
+// prefixWriter writes the prefix at the start of each line. Each
+// write is passed to the underlying writer with a single call so
+// lines from parallel writers are not broken up when each write
+// contains complete lines.
+type prefixWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	prefix  string
+	midLine bool
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var buf bytes.Buffer
+	for _, line := range bytes.SplitAfter(p, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		if !w.midLine {
+			buf.WriteString(w.prefix)
+		}
+		buf.Write(line)
+		w.midLine = line[len(line)-1] != '\n'
+	}
+
+	if _, err := w.w.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+var (
+	_ UI     = (*scopedUI)(nil)
+	_ Status = (*scopedStatus)(nil)
+	_ Step   = (*scopedStep)(nil)
+)
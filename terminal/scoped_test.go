@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package terminal
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoped(t *testing.T) {
+	require := require.New(t)
+
+	rec := NewRecordingUI()
+	ui := Scoped(rec, "default")
+
+	ui.Output("Booting\nWaiting", WithHeaderStyle())
+	ui.Output("failed", WithErrorStyle())
+	ui.Output("partial ", WithoutNewLine())
+	ui.Output("done")
+	tbl := NewTable("Name")
+	tbl.Rich([]string{"web"}, nil)
+	ui.Table(tbl)
+
+	sg := ui.StepGroup()
+	step := sg.Add("Step %d", 1)
+	fmt.Fprint(step.TermOutput(), "one\ntw")
+	fmt.Fprint(step.TermOutput(), "o\n")
+	step.Done()
+	sg.Wait()
+
+	events := rec.Events()
+	require.Equal("==> default: Booting\n    default: Waiting", events[0].Message)
+	require.Equal(HeaderStyle, events[0].Style)
+	require.Equal(scopeColor("default"), events[0].Color)
+	require.Equal("default", events[0].Target)
+	require.Equal("==> default: failed", events[1].Message)
+	require.Equal("red", events[1].Color)
+	require.Equal("==> default: partial ", events[2].Message)
+	require.Equal("done", events[3].Message)
+
+	require.Equal(EventOutput, events[4].Type)
+	for _, line := range strings.Split(events[4].Message, "\n") {
+		require.True(strings.HasPrefix(line, "    default: "), line)
+	}
+
+	require.Equal("==> default: Step 1", events[5].Message)
+	require.Equal("    default: one\n    default: tw", events[6].Data)
+	require.Equal("o\n", events[7].Data)
+}
+
+func TestScoped_machineReadable(t *testing.T) {
+	require := require.New(t)
+
+	rec := NewRecordingUI()
+	rec.MachineReadableOutput = true
+	ui := Scoped(rec, "default")
+
+	ui.Output("Booting")
+	ui.Output("other", WithTarget("web"))
+	ui.NamedValues([]NamedValue{{Name: "box", Value: "bionic"}})
+
+	events := rec.Events()
+	require.Equal("Booting", events[0].Message)
+	require.Equal("default", events[0].Target)
+	require.Empty(events[0].Color)
+	require.Equal("web", events[1].Target)
+	require.Equal("default", events[2].Target)
+}
+
+func TestScopeColor(t *testing.T) {
+	require.Equal(t, scopeColor("default"), scopeColor("default"))
+	require.Contains(t, scopeColors, scopeColor("web"))
+}
+
+func TestPrefixWriter_parallel(t *testing.T) {
+	var buf bytes.Buffer
+	var mu sync.Mutex
+	out := writerFunc(func(p []byte) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return buf.Write(p)
+	})
+
+	var wg sync.WaitGroup
+	for _, name := range []string{"one", "two", "three"} {
+		w := &prefixWriter{w: out, prefix: name + ": "}
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				fmt.Fprintf(w, "line %d from %s\n", i, name)
+			}
+		}(name)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 150)
+	for _, line := range lines {
+		name, rest, ok := strings.Cut(line, ": ")
+		require.True(t, ok, line)
+		require.True(t, strings.HasSuffix(rest, "from "+name), line)
+	}
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
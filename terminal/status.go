@@ -95,7 +95,7 @@ func (s *spinnerStatus) Update(msg string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.spinner.Suffix = " " + msg
+	s.spinner.Suffix = " " + Redact(msg)
 
 	if !s.running {
 		s.spinner.Start()
@@ -119,7 +119,7 @@ func (s *spinnerStatus) Step(status, msg string) {
 		pad = " "
 	}
 
-	fmt.Fprintf(color.Output, "%s%s %s\n", statusIcon, pad, msg)
+	fmt.Fprintf(color.Output, "%s%s %s\n", statusIcon, pad, Redact(msg))
 }
 
 func (s *spinnerStatus) Close() error {
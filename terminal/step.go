@@ -5,6 +5,7 @@ package terminal
 
 import (
 	"context"
+	"fmt"
 	"io"
 )
 
@@ -69,7 +70,8 @@ type fancyStep struct {
 	done   bool
 	status string
 
-	term *Term
+	term         *Term
+	redactedTerm io.Writer
 }
 
 func (f *fancyStep) TermOutput() io.Writer {
@@ -80,13 +82,14 @@ func (f *fancyStep) TermOutput() io.Writer {
 		}
 
 		f.term = t
+		f.redactedTerm = RedactWriter(t)
 	}
 
-	return f.term
+	return f.redactedTerm
 }
 
 func (f *fancyStep) Update(str string, args ...interface{}) {
-	f.ent.Update(str, args...)
+	f.ent.Update("%s", Redact(fmt.Sprintf(str, args...)))
 }
 
 func (f *fancyStep) Status(status string) {
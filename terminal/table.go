@@ -4,7 +4,18 @@
 package terminal
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
 	"github.com/fatih/color"
+	"github.com/mattn/go-runewidth"
 	"github.com/olekukonko/tablewriter"
 )
 
@@ -12,8 +23,50 @@ import (
 type Table struct {
 	Headers []string
 	Rows    [][]TableEntry
+
+	// Columns configures the width of each column, by position.
+	// Columns without configuration wrap values when the table
+	// does not fit within its width.
+	Columns []TableColumn `json:",omitempty"`
+
+	// Width is the widest the table may be. When zero the width
+	// of the terminal is used, if known.
+	Width int `json:",omitempty"`
 }
 
+// Overflow is how a value wider than its column is handled
+type Overflow int
+
+const (
+	// OverflowWrap wraps the value onto multiple lines
+	OverflowWrap Overflow = iota
+	// OverflowTruncate truncates the value and ends it with an ellipsis
+	OverflowTruncate
+)
+
+// TableColumn configures the width of a column of a Table
+type TableColumn struct {
+	// MinWidth is the width the column will not be shrunk below
+	// to fit the table within its width
+	MinWidth int `json:"min_width,omitempty"`
+
+	// MaxWidth is the widest the column may be. Zero means
+	// the column has no maximum width.
+	MaxWidth int `json:"max_width,omitempty"`
+
+	// Overflow is how values wider than the column are handled
+	Overflow Overflow `json:"overflow,omitempty"`
+}
+
+// Width of the padding and separator around each column
+const tableColumnPadding = 3
+
+// Width columns without a minimum will not be shrunk below
+const tableColumnMinWidth = 5
+
+// Ellipsis used to end truncated values
+const tableEllipsis = "…"
+
 // Table creates a new Table structure that can be used with UI.Table.
 func NewTable(headers ...string) *Table {
 	return &Table{
@@ -43,8 +96,357 @@ func (t *Table) Rich(cols []string, colors []string) {
 	t.Rows = append(t.Rows, row)
 }
 
+// SetColumn sets the configuration of the column at index i
+func (t *Table) SetColumn(i int, c TableColumn) {
+	for len(t.Columns) <= i {
+		t.Columns = append(t.Columns, TableColumn{})
+	}
+	t.Columns[i] = c
+}
+
+// ColumnIndex returns the index of the column with the header,
+// or -1 if there is no such column
+func (t *Table) ColumnIndex(header string) int {
+	for i, h := range t.Headers {
+		if h == header {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Sort sorts the rows by the values of the column. Numbers are sorted
+// before other values and compared as numbers. The sort is stable so
+// rows may be sorted by several columns by sorting by each in reverse
+// order.
+func (t *Table) Sort(column int, descending bool) {
+	value := func(row []TableEntry) string {
+		if column < len(row) {
+			return row[column].Value
+		}
+		return ""
+	}
+
+	sort.SliceStable(t.Rows, func(i, j int) bool {
+		a, b := value(t.Rows[i]), value(t.Rows[j])
+		if descending {
+			a, b = b, a
+		}
+
+		x, xnum := tableNumber(a)
+		y, ynum := tableNumber(b)
+		switch {
+		case xnum && ynum:
+			return x < y
+		case xnum != ynum:
+			return xnum
+		}
+
+		return a < b
+	})
+}
+
+// tableNumber parses the value as a number
+func tableNumber(v string) (float64, bool) {
+	n, err := strconv.ParseFloat(v, 64)
+	return n, err == nil && !math.IsNaN(n)
+}
+
+// Filter returns a copy of the table with only the rows for
+// which keep returns true
+func (t *Table) Filter(keep func(row []TableEntry) bool) *Table {
+	result := &Table{
+		Headers: t.Headers,
+		Rows:    make([][]TableEntry, 0, len(t.Rows)),
+		Columns: t.Columns,
+		Width:   t.Width,
+	}
+	for _, row := range t.Rows {
+		if keep(row) {
+			result.Rows = append(result.Rows, row)
+		}
+	}
+
+	return result
+}
+
+// CSV writes the headers and rows of the table as CSV. Sensitive
+// values are redacted.
+func (t *Table) CSV(w io.Writer) error {
+	tbl := redactTable(t)
+
+	cw := csv.NewWriter(w)
+	if len(tbl.Headers) > 0 {
+		if err := cw.Write(tbl.Headers); err != nil {
+			return err
+		}
+	}
+	for _, row := range tbl.Rows {
+		values := make([]string, len(row))
+		for i, ent := range row {
+			values[i] = ent.Value
+		}
+		if err := cw.Write(values); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// JSON writes the rows of the table as a JSON array of objects keyed
+// by the headers. Values of columns without a header are keyed by the
+// index of the column. Sensitive values are redacted.
+func (t *Table) JSON(w io.Writer) error {
+	tbl := redactTable(t)
+
+	rows := make([]map[string]string, len(tbl.Rows))
+	for i, row := range tbl.Rows {
+		rows[i] = map[string]string{}
+		for j, ent := range row {
+			key := strconv.Itoa(j)
+			if j < len(tbl.Headers) && tbl.Headers[j] != "" {
+				key = tbl.Headers[j]
+			}
+			rows[i][key] = ent.Value
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rows)
+}
+
+// tableWidth returns the width the table must fit within. The width
+// of the terminal is used when the table has no width and term is set.
+func tableWidth(tbl *Table, term bool) int {
+	if tbl.Width > 0 || !term {
+		return tbl.Width
+	}
+
+	return terminalWidth()
+}
+
+// column returns the configuration of the column at index i
+func (t *Table) column(i int) TableColumn {
+	if i < len(t.Columns) {
+		return t.Columns[i]
+	}
+
+	return TableColumn{}
+}
+
+// fit returns a copy of the table with values wrapped or truncated
+// so each column is within its maximum width, and the table is within
+// the width when it is greater than zero. Columns are shrunk, widest
+// first, until the table fits or no column can be shrunk further.
+// When wrap is not set, only the values of columns which truncate
+// are changed, so values are not broken up across lines.
+func (t *Table) fit(width int, wrap bool) *Table {
+	cols := len(t.Headers)
+	for _, row := range t.Rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+
+	// Start with the width of the widest value of each column
+	widths := make([]int, cols)
+	measure := func(i int, v string) {
+		for _, line := range strings.Split(v, "\n") {
+			if w := runewidth.StringWidth(line); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	for i, h := range t.Headers {
+		measure(i, h)
+	}
+	for _, row := range t.Rows {
+		for i, ent := range row {
+			measure(i, ent.Value)
+		}
+	}
+
+	floors := make([]int, cols)
+	for i := range widths {
+		c := t.column(i)
+
+		// Columns which are not changed keep their width
+		if !wrap && c.Overflow != OverflowTruncate {
+			floors[i] = widths[i]
+			continue
+		}
+
+		if c.MaxWidth > 0 && widths[i] > c.MaxWidth {
+			widths[i] = c.MaxWidth
+		}
+
+		floors[i] = tableColumnMinWidth
+		if c.MinWidth > 0 {
+			floors[i] = c.MinWidth
+		}
+		if floors[i] > widths[i] {
+			floors[i] = widths[i]
+		}
+	}
+
+	if width > 0 {
+		total := cols * tableColumnPadding
+		for _, w := range widths {
+			total += w
+		}
+		for ; total > width; total-- {
+			widest := -1
+			for i, w := range widths {
+				if w > floors[i] && (widest < 0 || w > widths[widest]) {
+					widest = i
+				}
+			}
+			if widest < 0 {
+				break
+			}
+			widths[widest]--
+		}
+	}
+
+	result := &Table{
+		Headers: make([]string, len(t.Headers)),
+		Rows:    make([][]TableEntry, len(t.Rows)),
+		Columns: t.Columns,
+		Width:   t.Width,
+	}
+	value := func(v string, i int) string {
+		overflow := t.column(i).Overflow
+		if !wrap && overflow != OverflowTruncate {
+			return v
+		}
+		return fitValue(v, widths[i], overflow)
+	}
+	for i, h := range t.Headers {
+		result.Headers[i] = value(h, i)
+	}
+	for i, row := range t.Rows {
+		result.Rows[i] = make([]TableEntry, len(row))
+		for j, ent := range row {
+			ent.Value = value(ent.Value, j)
+			result.Rows[i][j] = ent
+		}
+	}
+
+	return result
+}
+
+// fitValue returns the value wrapped or truncated to the width
+func fitValue(v string, width int, overflow Overflow) string {
+	if width <= 0 {
+		return v
+	}
+
+	lines := strings.Split(v, "\n")
+	var result []string
+	for _, line := range lines {
+		if runewidth.StringWidth(line) <= width {
+			result = append(result, line)
+			continue
+		}
+
+		if overflow == OverflowTruncate {
+			result = append(result, runewidth.Truncate(line, width, tableEllipsis))
+		} else {
+			result = append(result, wrapLine(line, width)...)
+		}
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// wrapLine wraps the line at spaces so each line is within the
+// width. Whitespace is kept as given, except where the line is
+// broken, and words wider than the width are split. An empty or
+// whitespace only line results in a single line.
+func wrapLine(line string, width int) []string {
+	var lines []string
+	var current, space string
+	for _, tok := range splitSpaces(line) {
+		if strings.TrimSpace(tok) == "" {
+			space = tok
+			continue
+		}
+
+		word := tok
+		if current == "" && len(lines) == 0 {
+			// Keep the indentation of the line
+			word, space = space+word, ""
+		}
+
+		if current != "" &&
+			runewidth.StringWidth(current)+runewidth.StringWidth(space)+runewidth.StringWidth(word) <= width {
+			current += space + word
+		} else {
+			if current != "" {
+				lines = append(lines, current)
+			}
+			lines, current = splitWide(lines, word, width)
+		}
+		space = ""
+	}
+
+	switch {
+	case current == "" && len(lines) == 0:
+		lines, current = splitWide(lines, space, width)
+	case runewidth.StringWidth(current)+runewidth.StringWidth(space) <= width:
+		current += space
+	}
+	if current != "" || len(lines) == 0 {
+		lines = append(lines, current)
+	}
+
+	return lines
+}
+
+// splitWide appends the beginning of the word to lines until the
+// remaining word is within the width
+func splitWide(lines []string, word string, width int) ([]string, string) {
+	for runewidth.StringWidth(word) > width {
+		head := runewidth.Truncate(word, width, "")
+		if head == "" {
+			// Always make progress when a single rune is too wide
+			_, size := utf8.DecodeRuneInString(word)
+			head = word[:size]
+		}
+		lines = append(lines, head)
+		word = word[len(head):]
+	}
+
+	return lines, word
+}
+
+// splitSpaces splits the line into runs of whitespace and runs
+// of other characters
+func splitSpaces(line string) []string {
+	var result []string
+	start, space := 0, false
+	for i, r := range line {
+		if i == 0 {
+			space = unicode.IsSpace(r)
+		} else if unicode.IsSpace(r) != space {
+			result = append(result, line[start:i])
+			start, space = i, !space
+		}
+	}
+	if start < len(line) {
+		result = append(result, line[start:])
+	}
+
+	return result
+}
+
 // Table implements UI
 func (u *basicUI) Table(tbl *Table, opts ...Option) {
+	tbl = redactTable(tbl).fit(tableWidth(tbl, true), true)
 	// Build our config and set our options
 	cfg := &config{Writer: color.Output}
 	for _, opt := range opts {
@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package terminal
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testTable() *Table {
+	tbl := NewTable("Name", "Size", "Description")
+	tbl.Rich([]string{"hashicorp/bionic64", "10", "Ubuntu 18.04 LTS 64-bit box"}, nil)
+	tbl.Rich([]string{"generic/alpine", "9", "Alpine Linux"}, nil)
+	tbl.Rich([]string{"bento/debian-11", "100", "Debian 11 built with packer"}, nil)
+
+	return tbl
+}
+
+func TestTable_fit(t *testing.T) {
+	t.Run("max width truncates", func(t *testing.T) {
+		tbl := testTable()
+		tbl.SetColumn(0, TableColumn{MaxWidth: 10, Overflow: OverflowTruncate})
+
+		result := tbl.fit(0, true)
+		require.Equal(t, "hashicorp…", result.Rows[0][0].Value)
+		require.Equal(t, "generic/a…", result.Rows[1][0].Value)
+		require.Equal(t, tbl.Rows[0][2].Value, result.Rows[0][2].Value)
+	})
+
+	t.Run("max width wraps", func(t *testing.T) {
+		tbl := testTable()
+		tbl.SetColumn(2, TableColumn{MaxWidth: 12})
+
+		result := tbl.fit(0, true)
+		require.Equal(t, "Ubuntu 18.04\nLTS 64-bit\nbox", result.Rows[0][2].Value)
+		require.Equal(t, "Alpine Linux", result.Rows[1][2].Value)
+		require.Equal(t, "Description", result.Headers[2])
+	})
+
+	t.Run("width shrinks widest column", func(t *testing.T) {
+		tbl := testTable()
+		tbl.SetColumn(0, TableColumn{MinWidth: 18})
+
+		result := tbl.fit(18+4+20+3*tableColumnPadding, true)
+		require.Equal(t, "hashicorp/bionic64", result.Rows[0][0].Value)
+		for _, row := range result.Rows {
+			for _, line := range strings.Split(row[2].Value, "\n") {
+				require.LessOrEqual(t, len(line), 20)
+			}
+		}
+	})
+
+	t.Run("columns are not shrunk below minimum", func(t *testing.T) {
+		tbl := testTable()
+		result := tbl.fit(10, true)
+		require.Equal(t, "10", result.Rows[0][1].Value)
+		lines := strings.Split(result.Rows[0][0].Value, "\n")
+		require.Equal(t, []string{"hashi", "corp/", "bioni", "c64"}, lines)
+	})
+
+	t.Run("without wrapping only truncates", func(t *testing.T) {
+		tbl := testTable()
+		tbl.Rich([]string{"local/dev", "1", "two  spaces kept"}, nil)
+		tbl.SetColumn(0, TableColumn{MaxWidth: 10, Overflow: OverflowTruncate})
+		tbl.SetColumn(2, TableColumn{MaxWidth: 12})
+
+		result := tbl.fit(0, false)
+		require.Equal(t, "hashicorp…", result.Rows[0][0].Value)
+		require.Equal(t, "Ubuntu 18.04 LTS 64-bit box", result.Rows[0][2].Value)
+		require.Equal(t, "two  spaces kept", result.Rows[3][2].Value)
+
+		// Only columns which truncate are shrunk to fit the width
+		result = tbl.fit(40, false)
+		require.Equal(t, "hash…", result.Rows[0][0].Value)
+		require.Equal(t, "Ubuntu 18.04 LTS 64-bit box", result.Rows[0][2].Value)
+	})
+
+	t.Run("wide words are split", func(t *testing.T) {
+		require.Equal(t, []string{"abc", "def", "g h"}, wrapLine("abcdefg h", 3))
+	})
+
+	t.Run("whitespace is kept", func(t *testing.T) {
+		require.Equal(t, []string{"  a  b", "c"}, wrapLine("  a  b c", 6))
+		require.Equal(t, []string{"a  b  "}, wrapLine("a  b  ", 6))
+		require.Equal(t, []string{"    ", "  "}, wrapLine("      ", 4))
+		require.Equal(t, []string{""}, wrapLine("", 4))
+
+		require.Equal(t, "one\n\n  two\n   \nthree", fitValue("one\n\n  two\n   \nthree", 5, OverflowWrap))
+	})
+}
+
+func TestTable_sort(t *testing.T) {
+	tbl := testTable()
+
+	tbl.Sort(tbl.ColumnIndex("Size"), false)
+	require.Equal(t, "9", tbl.Rows[0][1].Value)
+	require.Equal(t, "100", tbl.Rows[2][1].Value)
+
+	tbl.Sort(tbl.ColumnIndex("Name"), true)
+	require.Equal(t, "hashicorp/bionic64", tbl.Rows[0][0].Value)
+	require.Equal(t, "bento/debian-11", tbl.Rows[2][0].Value)
+
+	require.Equal(t, -1, tbl.ColumnIndex("Unknown"))
+}
+
+func TestTable_sortMixed(t *testing.T) {
+	tbl := NewTable("Value")
+	for _, v := range []string{"b", "10", "NaN", "a", "9", "", "-1"} {
+		tbl.Rich([]string{v}, nil)
+	}
+
+	values := func() []string {
+		var result []string
+		for _, row := range tbl.Rows {
+			result = append(result, row[0].Value)
+		}
+		return result
+	}
+
+	tbl.Sort(0, false)
+	require.Equal(t, []string{"-1", "9", "10", "", "NaN", "a", "b"}, values())
+
+	tbl.Sort(0, true)
+	require.Equal(t, []string{"b", "a", "NaN", "", "10", "9", "-1"}, values())
+}
+
+func TestTable_filter(t *testing.T) {
+	tbl := testTable()
+	tbl.Width = 40
+
+	result := tbl.Filter(func(row []TableEntry) bool {
+		return strings.Contains(row[2].Value, "Linux")
+	})
+	require.Len(t, result.Rows, 1)
+	require.Equal(t, "generic/alpine", result.Rows[0][0].Value)
+	require.Equal(t, 40, result.Width)
+	require.Len(t, tbl.Rows, 3)
+}
+
+func TestTable_export(t *testing.T) {
+	AddSensitive("secret")
+	defer ClearSensitive()
+
+	tbl := NewTable("Name", "Value")
+	tbl.Rich([]string{"password", "secret"}, nil)
+	tbl.Rich([]string{"list", "a,b"}, nil)
+
+	var buf bytes.Buffer
+	require.NoError(t, tbl.CSV(&buf))
+	require.Equal(t, "Name,Value\npassword,"+RedactedValue+"\nlist,\"a,b\"\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, tbl.JSON(&buf))
+	require.JSONEq(t, `[
+		{"Name": "password", "Value": "`+RedactedValue+`"},
+		{"Name": "list", "Value": "a,b"}
+	]`, buf.String())
+}
@@ -72,6 +72,12 @@ type UI interface {
 	// NOT be called.
 	Status() Status
 
+	// Progress returns a live-updating progress of an operation measured
+	// in bytes, such as a download. The total is the number of bytes
+	// expected, or zero if unknown. While a Progress is live (Close isn't
+	// called), other methods on UI should NOT be called.
+	Progress(msg string, total int64) Progress
+
 	// Table outputs the information formatted into a Table structure.
 	Table(*Table, ...Option)
 
@@ -139,6 +145,21 @@ func ConsoleUI(ctx context.Context) UI {
 	}
 }
 
+// terminalWidth returns the width of the terminal of stdout, or
+// zero if stdout is not a terminal
+func terminalWidth() int {
+	c, err := console.ConsoleFromFile(os.Stdout)
+	if err != nil {
+		return 0
+	}
+	sz, err := c.Size()
+	if err != nil {
+		return 0
+	}
+
+	return int(sz.Width)
+}
+
 // Interpret decomposes the msg and arguments into the message, style, disabled new line, writer, and color
 func Interpret(msg string, raw ...interface{}) (string, string, bool, io.Writer, string) {
 	// Build our args and options
@@ -152,8 +173,8 @@ func Interpret(msg string, raw ...interface{}) (string, string, bool, io.Writer,
 		}
 	}
 
-	// Build our message
-	msg = fmt.Sprintf(msg, args...)
+	// Build our message with any sensitive values removed
+	msg = Redact(fmt.Sprintf(msg, args...))
 
 	// Build our config and set our options
 	cfg := &config{Writer: color.Output}
@@ -164,6 +185,20 @@ func Interpret(msg string, raw ...interface{}) (string, string, bool, io.Writer,
 	return msg, cfg.Style, cfg.DisableNewLine, cfg.Writer, cfg.Color
 }
 
+// OptionsTarget returns the target set by the options, if any. Values
+// within raw which are not options are ignored, so this may be used
+// with the arguments given to Output.
+func OptionsTarget(raw ...interface{}) string {
+	cfg := &config{}
+	for _, r := range raw {
+		if opt, ok := r.(Option); ok {
+			opt(cfg)
+		}
+	}
+
+	return cfg.Target
+}
+
 const (
 	HeaderStyle      = "header"
 	ErrorStyle       = "error"
@@ -188,6 +223,10 @@ type config struct {
 
 	// Color of the message when it is output to the writer
 	Color string
+
+	// Target the output is related to, like the name of a machine.
+	// This is only included within machine readable output.
+	Target string
 }
 
 // Option controls output styling.
@@ -308,6 +347,12 @@ func WithColor(color string) Option {
 	return func(c *config) { c.Color = color }
 }
 
+// WithTarget specifies the target the output is related to, like
+// the name of a machine. This is only used by machine readable UIs.
+func WithTarget(name string) Option {
+	return func(c *config) { c.Target = name }
+}
+
 var (
 	colorInfo     = color.New()
 	colorInfoBold = color.New(color.Bold)